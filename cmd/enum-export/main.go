@@ -8,6 +8,7 @@ import (
 
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/job"
 )
 
 type EnumSet struct {
@@ -30,15 +31,20 @@ func main() {
 		{Name: "MediaOrdinalAllValues", Enums: toStringSlice(dto.MediaOrdinalAllValues)},
 		{Name: "PersonOrdinalAllValues", Enums: toStringSlice(dto.PersonOrdinalAllValues)},
 		{Name: "TagOrdinalAllValues", Enums: toStringSlice(dto.TagOrdinalAllValues)},
+		{Name: "JobLogLevelAllValues", Enums: toStringSlice(model.JobLogLevelEnumAllValues)},
 		{Name: "JobStatusAllValues", Enums: toStringSlice(model.JobStatusEnumAllValues)},
-		{Name: "JobTypeAllValues", Enums: toStringSlice(model.JobTypeEnumAllValues)},
+		{Name: "JobTreeStatusAllValues", Enums: toStringSlice(dto.JobTreeStatusAllValues)},
+		{Name: "JobTypeAllValues", Enums: toStringSlice(job.Registry.Types())},
 		{Name: "MediaTypeAllValues", Enums: toStringSlice(model.MediaTypeEnumAllValues)},
 		{Name: "MediaRelationTypeAllValues", Enums: toStringSlice(model.MediaRelationTypeEnumAllValues)},
 		{Name: "WSTopicAllValues", Enums: toStringSlice(dto.WSTopicAllValues)},
 		{Name: "WatchStatusAllValues", Enums: toStringSlice(dto.WatchStatusAllValues)},
+		{Name: "WatchModeAllValues", Enums: toStringSlice(model.WatchModeEnumAllValues)},
+		{Name: "DuplicateKeyAllValues", Enums: toStringSlice(dto.DuplicateKeyAllValues)},
+		{Name: "ChecksumAlgorithmAllValues", Enums: toStringSlice(model.ChecksumAlgorithmEnumAllValues)},
 	}
 
-	lines := []string{}
+	lines := jobDataTypes()
 	for _, e := range enums {
 		log.Printf("Generating type for %v", e.Name)
 		lines = append(lines, fmt.Sprintf(
@@ -66,3 +72,24 @@ func main() {
 
 	log.Print("Done generating enums")
 }
+
+// jobDataTypes exports the payload type of every registered job type.
+// The payload types themselves are generated by tygo
+func jobDataTypes() []string {
+	payloads := []string{}
+	for _, p := range job.Registry.Payloads() {
+		payloads = append(payloads, p.Name())
+	}
+
+	byType := []string{}
+	for _, t := range job.Registry.Types() {
+		h, _ := job.Registry.Get(t)
+		byType = append(byType, fmt.Sprintf("%v: %v", t, h.Payload.Name()))
+	}
+
+	return []string{
+		fmt.Sprintf(`import type { %v } from "./index"`, strings.Join(payloads, ", ")),
+		fmt.Sprintf("export type JobData = %v", strings.Join(payloads, " | ")),
+		fmt.Sprintf("export type JobDataByType = { %v }", strings.Join(byType, "; ")),
+	}
+}
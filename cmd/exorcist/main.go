@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"sync"
 	"syscall"
@@ -12,7 +13,11 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/job"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/repository"
 	"github.com/slugger7/exorcist/internal/server"
+	"github.com/slugger7/exorcist/internal/websockets"
 )
 
 func gracefulShutdown(apiServer *http.Server, done chan bool, wg *sync.WaitGroup) {
@@ -37,6 +42,30 @@ func gracefulShutdown(apiServer *http.Server, done chan bool, wg *sync.WaitGroup
 	done <- true
 }
 
+// runWorker only runs jobs without serving the api so that jobs can be spread over multiple processes
+func runWorker(env *environment.EnvironmentVariables) {
+	shutdownCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	lg := logger.New(env)
+	repo := repository.New(env, shutdownCtx)
+
+	job.RecoverInterrupted(env, repo, lg)
+
+	var wg sync.WaitGroup
+	ch := job.New(env, nil, lg, shutdownCtx, &wg, websockets.New(env))
+	ch <- true // start if any jobs exist
+
+	lg.Infof("Worker %v started", env.WorkerId)
+
+	<-shutdownCtx.Done()
+
+	log.Println("Waiting for job runner to finish")
+	wg.Wait()
+
+	log.Println("Worker exiting")
+}
+
 func main() {
 	err := godotenv.Load()
 	if err != nil {
@@ -44,6 +73,11 @@ func main() {
 	}
 	env := environment.GetEnvironmentVariables()
 
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		runWorker(env)
+		return
+	}
+
 	var wg sync.WaitGroup
 	server := server.New(env, &wg)
 
@@ -3,6 +3,7 @@ module github.com/slugger7/exorcist
 go 1.24.0
 
 require (
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/gin-contrib/cors v1.7.3
 	github.com/gin-contrib/sessions v1.0.2
 	github.com/gin-contrib/static v1.1.5
@@ -15,10 +16,13 @@ require (
 	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.24 // remove after migration
 	github.com/u2takey/ffmpeg-go v0.5.0
+	github.com/zeebo/xxh3 v1.1.0
 	go.uber.org/mock v0.5.0
 	golang.org/x/crypto v0.37.0
 )
 
+require github.com/stretchr/testify v1.10.0
+
 require (
 	github.com/aws/aws-sdk-go v1.55.6 // indirect
 	github.com/bytedance/sonic v1.13.2 // indirect
@@ -47,7 +51,6 @@ require (
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.3 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/u2takey/go-utils v0.3.1 // indirect
 	github.com/ugorji/go/codec v1.2.12 // indirect
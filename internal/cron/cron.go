@@ -0,0 +1,178 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed standard five field cron expression (minute hour day-of-month month day-of-week)
+type Schedule struct {
+	minute     []bool
+	hour       []bool
+	dayOfMonth []bool
+	month      []bool
+	dayOfWeek  []bool
+	// standard cron matches either day field when both are restricted
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+type bounds struct {
+	min int
+	max int
+}
+
+var (
+	minuteBounds     = bounds{0, 59}
+	hourBounds       = bounds{0, 23}
+	dayOfMonthBounds = bounds{1, 31}
+	monthBounds      = bounds{1, 12}
+	dayOfWeekBounds  = bounds{0, 7}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// maxSearch limits how far into the future Next will look for a matching time
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+func Parse(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := macros[expression]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression but got %v: %v", len(fields), expression)
+	}
+
+	var err error
+	s := &Schedule{}
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.dayOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if s.dayOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is accepted as sunday
+	s.dayOfWeek[0] = s.dayOfWeek[0] || s.dayOfWeek[7]
+	s.dayOfMonthRestricted = fields[2] != "*"
+	s.dayOfWeekRestricted = fields[4] != "*"
+
+	return s, nil
+}
+
+func parseField(field string, b bounds) ([]bool, error) {
+	values := make([]bool, b.max+1)
+	for _, part := range strings.Split(field, ",") {
+		if err := parsePart(part, b, values); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func parsePart(part string, b bounds, values []bool) error {
+	step := 1
+	rangePart := part
+	if i := strings.Index(part, "/"); i != -1 {
+		s, err := strconv.Atoi(part[i+1:])
+		if err != nil || s < 1 {
+			return fmt.Errorf("invalid step in %v", part)
+		}
+		step = s
+		rangePart = part[:i]
+	}
+
+	start, end := b.min, b.max
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		bits := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if start, err = strconv.Atoi(bits[0]); err != nil {
+			return fmt.Errorf("invalid range start in %v", part)
+		}
+		if end, err = strconv.Atoi(bits[1]); err != nil {
+			return fmt.Errorf("invalid range end in %v", part)
+		}
+	default:
+		value, err := strconv.Atoi(rangePart)
+		if err != nil {
+			return fmt.Errorf("invalid value %v", part)
+		}
+		start = value
+		if step == 1 {
+			end = value
+		}
+	}
+
+	if start < b.min || end > b.max || start > end {
+		return fmt.Errorf("%v is out of range %v-%v", part, b.min, b.max)
+	}
+
+	for i := start; i <= end; i += step {
+		values[i] = true
+	}
+
+	return nil
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth[t.Day()]
+	dow := s.dayOfWeek[int(t.Weekday())]
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+// Next returns the first time after t that matches the schedule. A zero time is returned when nothing matches.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		if !s.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_WithInvalidFieldCount_ShouldError(t *testing.T) {
+	_, err := Parse("* * * *")
+	assert.NotNil(t, err)
+}
+
+func Test_Parse_WithOutOfRangeValue_ShouldError(t *testing.T) {
+	_, err := Parse("60 * * * *")
+	assert.NotNil(t, err)
+}
+
+func Test_Next_Nightly(t *testing.T) {
+	s, err := Parse("0 3 * * *")
+	assert.Nil(t, err)
+
+	from := time.Date(2025, 8, 10, 14, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 8, 11, 3, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func Test_Next_Weekly(t *testing.T) {
+	s, err := Parse("@weekly")
+	assert.Nil(t, err)
+
+	// 2025-08-10 is a sunday
+	from := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func Test_Next_WithStepsAndLists(t *testing.T) {
+	s, err := Parse("*/15 9-17 * * 1,3")
+	assert.Nil(t, err)
+
+	// 2025-08-11 is a monday
+	from := time.Date(2025, 8, 11, 17, 50, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 8, 13, 9, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func Test_Next_WithDayOfMonthAndDayOfWeek_ShouldMatchEither(t *testing.T) {
+	s, err := Parse("0 0 1 * 5")
+	assert.Nil(t, err)
+
+	// 2025-08-14 is a thursday
+	from := time.Date(2025, 8, 14, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func Test_Next_WithImpossibleDate_ShouldReturnZero(t *testing.T) {
+	s, err := Parse("0 0 31 2 *")
+	assert.Nil(t, err)
+
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func Test_Next_WithSundayAsSeven(t *testing.T) {
+	s, err := Parse("30 2 * * 5-7")
+	assert.Nil(t, err)
+
+	// 2025-08-10 is a sunday
+	from := time.Date(2025, 8, 10, 1, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 8, 10, 2, 30, 0, 0, time.UTC), s.Next(from))
+}
@@ -13,13 +13,22 @@ import (
 )
 
 type Job struct {
-	ID       uuid.UUID `sql:"primary_key"`
-	Parent   *uuid.UUID
-	Priority int16
-	Status   JobStatusEnum
-	Data     *string
-	Outcome  *string
-	Created  time.Time
-	Modified time.Time
-	JobType  JobTypeEnum
+	ID                uuid.UUID `sql:"primary_key"`
+	Parent            *uuid.UUID
+	Priority          int16
+	Status            JobStatusEnum
+	Data              *string
+	Outcome           *string
+	Created           time.Time
+	Modified          time.Time
+	JobType           JobTypeEnum
+	Attempts          int16
+	RunAfter          time.Time
+	Started           *time.Time
+	ProgressProcessed int32
+	ProgressTotal     int32
+	ProgressMessage   *string
+	Worker            *string
+	Heartbeat         *time.Time
+	DedupKey          *string
 }
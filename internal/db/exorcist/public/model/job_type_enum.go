@@ -12,15 +12,17 @@ import "errors"
 type JobTypeEnum string
 
 const (
-	JobTypeEnum_UpdateExistingVideos    JobTypeEnum = "update_existing_videos"
-	JobTypeEnum_ScanPath                JobTypeEnum = "scan_path"
-	JobTypeEnum_GenerateChecksum        JobTypeEnum = "generate_checksum"
-	JobTypeEnum_GenerateThumbnail       JobTypeEnum = "generate_thumbnail"
-	JobTypeEnum_ScanLibrary             JobTypeEnum = "scan_library"
-	JobTypeEnum_RefreshMetadata         JobTypeEnum = "refresh_metadata"
-	JobTypeEnum_RefreshLibraryMetadata  JobTypeEnum = "refresh_library_metadata"
-	JobTypeEnum_GenerateChapters        JobTypeEnum = "generate_chapters"
-	JobTypeEnum_GenerateLibraryChapters JobTypeEnum = "generate_library_chapters"
+	JobTypeEnum_UpdateExistingVideos        JobTypeEnum = "update_existing_videos"
+	JobTypeEnum_ScanPath                    JobTypeEnum = "scan_path"
+	JobTypeEnum_GenerateChecksum            JobTypeEnum = "generate_checksum"
+	JobTypeEnum_GenerateThumbnail           JobTypeEnum = "generate_thumbnail"
+	JobTypeEnum_ScanLibrary                 JobTypeEnum = "scan_library"
+	JobTypeEnum_RefreshMetadata             JobTypeEnum = "refresh_metadata"
+	JobTypeEnum_RefreshLibraryMetadata      JobTypeEnum = "refresh_library_metadata"
+	JobTypeEnum_GenerateChapters            JobTypeEnum = "generate_chapters"
+	JobTypeEnum_GenerateLibraryChapters     JobTypeEnum = "generate_library_chapters"
+	JobTypeEnum_GenerateFingerprint         JobTypeEnum = "generate_fingerprint"
+	JobTypeEnum_GenerateLibraryFingerprints JobTypeEnum = "generate_library_fingerprints"
 )
 
 var JobTypeEnumAllValues = []JobTypeEnum{
@@ -33,6 +35,8 @@ var JobTypeEnumAllValues = []JobTypeEnum{
 	JobTypeEnum_RefreshLibraryMetadata,
 	JobTypeEnum_GenerateChapters,
 	JobTypeEnum_GenerateLibraryChapters,
+	JobTypeEnum_GenerateFingerprint,
+	JobTypeEnum_GenerateLibraryFingerprints,
 }
 
 func (e *JobTypeEnum) Scan(value interface{}) error {
@@ -65,6 +69,10 @@ func (e *JobTypeEnum) Scan(value interface{}) error {
 		*e = JobTypeEnum_GenerateChapters
 	case "generate_library_chapters":
 		*e = JobTypeEnum_GenerateLibraryChapters
+	case "generate_fingerprint":
+		*e = JobTypeEnum_GenerateFingerprint
+	case "generate_library_fingerprints":
+		*e = JobTypeEnum_GenerateLibraryFingerprints
 	default:
 		return errors.New("jet: Invalid scan value '" + enumValue + "' for JobTypeEnum enum")
 	}
@@ -13,10 +13,11 @@ import (
 )
 
 type Library struct {
-	ID          uuid.UUID `sql:"primary_key"`
-	Name        string
-	LibraryType LibraryTypeEnum
-	Created     time.Time
-	Modified    time.Time
-	GhostID     *int32
+	ID           uuid.UUID `sql:"primary_key"`
+	Name         string
+	LibraryType  LibraryTypeEnum
+	Created      time.Time
+	Modified     time.Time
+	GhostID      *int32
+	ScanSettings *string
 }
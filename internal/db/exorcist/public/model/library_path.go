@@ -19,4 +19,5 @@ type LibraryPath struct {
 	Created   time.Time
 	Modified  time.Time
 	GhostID   *int32
+	Watch     WatchModeEnum
 }
@@ -13,17 +13,21 @@ import (
 )
 
 type Media struct {
-	ID            uuid.UUID `sql:"primary_key"`
-	LibraryPathID uuid.UUID
-	Path          string
-	Title         string
-	MediaType     MediaTypeEnum
-	Size          int64
-	Checksum      *string
-	Added         time.Time
-	Deleted       bool
-	Exists        bool
-	Created       time.Time
-	Modified      time.Time
-	GhostID       *int32
+	ID                       uuid.UUID `sql:"primary_key"`
+	LibraryPathID            uuid.UUID
+	Path                     string
+	Title                    string
+	MediaType                MediaTypeEnum
+	Size                     int64
+	Checksum                 *string
+	Added                    time.Time
+	Deleted                  bool
+	Exists                   bool
+	Created                  time.Time
+	Modified                 time.Time
+	GhostID                  *int32
+	FileModified             *time.Time
+	ChecksumAlgorithm        *ChecksumAlgorithmEnum
+	PartialChecksum          *string
+	PartialChecksumAlgorithm *ChecksumAlgorithmEnum
 }
@@ -12,10 +12,11 @@ import (
 )
 
 type Video struct {
-	ID      uuid.UUID `sql:"primary_key"`
-	MediaID uuid.UUID
-	Height  int32
-	Width   int32
-	Runtime float64
-	GhostID *int32
+	ID          uuid.UUID `sql:"primary_key"`
+	MediaID     uuid.UUID
+	Height      int32
+	Width       int32
+	Runtime     float64
+	GhostID     *int32
+	Fingerprint *[]byte
 }
@@ -17,15 +17,24 @@ type jobTable struct {
 	postgres.Table
 
 	// Columns
-	ID       postgres.ColumnString
-	Parent   postgres.ColumnString
-	Priority postgres.ColumnInteger
-	Status   postgres.ColumnString
-	Data     postgres.ColumnString
-	Outcome  postgres.ColumnString
-	Created  postgres.ColumnTimestamp
-	Modified postgres.ColumnTimestamp
-	JobType  postgres.ColumnString
+	ID                postgres.ColumnString
+	Parent            postgres.ColumnString
+	Priority          postgres.ColumnInteger
+	Status            postgres.ColumnString
+	Data              postgres.ColumnString
+	Outcome           postgres.ColumnString
+	Created           postgres.ColumnTimestamp
+	Modified          postgres.ColumnTimestamp
+	JobType           postgres.ColumnString
+	Attempts          postgres.ColumnInteger
+	RunAfter          postgres.ColumnTimestamp
+	Started           postgres.ColumnTimestamp
+	ProgressProcessed postgres.ColumnInteger
+	ProgressTotal     postgres.ColumnInteger
+	ProgressMessage   postgres.ColumnString
+	Worker            postgres.ColumnString
+	Heartbeat         postgres.ColumnTimestamp
+	DedupKey          postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -66,32 +75,50 @@ func newJobTable(schemaName, tableName, alias string) *JobTable {
 
 func newJobTableImpl(schemaName, tableName, alias string) jobTable {
 	var (
-		IDColumn       = postgres.StringColumn("id")
-		ParentColumn   = postgres.StringColumn("parent")
-		PriorityColumn = postgres.IntegerColumn("priority")
-		StatusColumn   = postgres.StringColumn("status")
-		DataColumn     = postgres.StringColumn("data")
-		OutcomeColumn  = postgres.StringColumn("outcome")
-		CreatedColumn  = postgres.TimestampColumn("created")
-		ModifiedColumn = postgres.TimestampColumn("modified")
-		JobTypeColumn  = postgres.StringColumn("job_type")
-		allColumns     = postgres.ColumnList{IDColumn, ParentColumn, PriorityColumn, StatusColumn, DataColumn, OutcomeColumn, CreatedColumn, ModifiedColumn, JobTypeColumn}
-		mutableColumns = postgres.ColumnList{ParentColumn, PriorityColumn, StatusColumn, DataColumn, OutcomeColumn, CreatedColumn, ModifiedColumn, JobTypeColumn}
+		IDColumn                = postgres.StringColumn("id")
+		ParentColumn            = postgres.StringColumn("parent")
+		PriorityColumn          = postgres.IntegerColumn("priority")
+		StatusColumn            = postgres.StringColumn("status")
+		DataColumn              = postgres.StringColumn("data")
+		OutcomeColumn           = postgres.StringColumn("outcome")
+		CreatedColumn           = postgres.TimestampColumn("created")
+		ModifiedColumn          = postgres.TimestampColumn("modified")
+		JobTypeColumn           = postgres.StringColumn("job_type")
+		AttemptsColumn          = postgres.IntegerColumn("attempts")
+		RunAfterColumn          = postgres.TimestampColumn("run_after")
+		StartedColumn           = postgres.TimestampColumn("started")
+		ProgressProcessedColumn = postgres.IntegerColumn("progress_processed")
+		ProgressTotalColumn     = postgres.IntegerColumn("progress_total")
+		ProgressMessageColumn   = postgres.StringColumn("progress_message")
+		WorkerColumn            = postgres.StringColumn("worker")
+		HeartbeatColumn         = postgres.TimestampColumn("heartbeat")
+		DedupKeyColumn          = postgres.StringColumn("dedup_key")
+		allColumns              = postgres.ColumnList{IDColumn, ParentColumn, PriorityColumn, StatusColumn, DataColumn, OutcomeColumn, CreatedColumn, ModifiedColumn, JobTypeColumn, AttemptsColumn, RunAfterColumn, StartedColumn, ProgressProcessedColumn, ProgressTotalColumn, ProgressMessageColumn, WorkerColumn, HeartbeatColumn, DedupKeyColumn}
+		mutableColumns          = postgres.ColumnList{ParentColumn, PriorityColumn, StatusColumn, DataColumn, OutcomeColumn, CreatedColumn, ModifiedColumn, JobTypeColumn, AttemptsColumn, RunAfterColumn, StartedColumn, ProgressProcessedColumn, ProgressTotalColumn, ProgressMessageColumn, WorkerColumn, HeartbeatColumn, DedupKeyColumn}
 	)
 
 	return jobTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:       IDColumn,
-		Parent:   ParentColumn,
-		Priority: PriorityColumn,
-		Status:   StatusColumn,
-		Data:     DataColumn,
-		Outcome:  OutcomeColumn,
-		Created:  CreatedColumn,
-		Modified: ModifiedColumn,
-		JobType:  JobTypeColumn,
+		ID:                IDColumn,
+		Parent:            ParentColumn,
+		Priority:          PriorityColumn,
+		Status:            StatusColumn,
+		Data:              DataColumn,
+		Outcome:           OutcomeColumn,
+		Created:           CreatedColumn,
+		Modified:          ModifiedColumn,
+		JobType:           JobTypeColumn,
+		Attempts:          AttemptsColumn,
+		RunAfter:          RunAfterColumn,
+		Started:           StartedColumn,
+		ProgressProcessed: ProgressProcessedColumn,
+		ProgressTotal:     ProgressTotalColumn,
+		ProgressMessage:   ProgressMessageColumn,
+		Worker:            WorkerColumn,
+		Heartbeat:         HeartbeatColumn,
+		DedupKey:          DedupKeyColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,12 +17,13 @@ type libraryTable struct {
 	postgres.Table
 
 	// Columns
-	ID          postgres.ColumnString
-	Name        postgres.ColumnString
-	LibraryType postgres.ColumnString
-	Created     postgres.ColumnTimestamp
-	Modified    postgres.ColumnTimestamp
-	GhostID     postgres.ColumnInteger
+	ID           postgres.ColumnString
+	Name         postgres.ColumnString
+	LibraryType  postgres.ColumnString
+	Created      postgres.ColumnTimestamp
+	Modified     postgres.ColumnTimestamp
+	GhostID      postgres.ColumnInteger
+	ScanSettings postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +64,28 @@ func newLibraryTable(schemaName, tableName, alias string) *LibraryTable {
 
 func newLibraryTableImpl(schemaName, tableName, alias string) libraryTable {
 	var (
-		IDColumn          = postgres.StringColumn("id")
-		NameColumn        = postgres.StringColumn("name")
-		LibraryTypeColumn = postgres.StringColumn("library_type")
-		CreatedColumn     = postgres.TimestampColumn("created")
-		ModifiedColumn    = postgres.TimestampColumn("modified")
-		GhostIDColumn     = postgres.IntegerColumn("ghost_id")
-		allColumns        = postgres.ColumnList{IDColumn, NameColumn, LibraryTypeColumn, CreatedColumn, ModifiedColumn, GhostIDColumn}
-		mutableColumns    = postgres.ColumnList{NameColumn, LibraryTypeColumn, CreatedColumn, ModifiedColumn, GhostIDColumn}
+		IDColumn           = postgres.StringColumn("id")
+		NameColumn         = postgres.StringColumn("name")
+		LibraryTypeColumn  = postgres.StringColumn("library_type")
+		CreatedColumn      = postgres.TimestampColumn("created")
+		ModifiedColumn     = postgres.TimestampColumn("modified")
+		GhostIDColumn      = postgres.IntegerColumn("ghost_id")
+		ScanSettingsColumn = postgres.StringColumn("scan_settings")
+		allColumns         = postgres.ColumnList{IDColumn, NameColumn, LibraryTypeColumn, CreatedColumn, ModifiedColumn, GhostIDColumn, ScanSettingsColumn}
+		mutableColumns     = postgres.ColumnList{NameColumn, LibraryTypeColumn, CreatedColumn, ModifiedColumn, GhostIDColumn, ScanSettingsColumn}
 	)
 
 	return libraryTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:          IDColumn,
-		Name:        NameColumn,
-		LibraryType: LibraryTypeColumn,
-		Created:     CreatedColumn,
-		Modified:    ModifiedColumn,
-		GhostID:     GhostIDColumn,
+		ID:           IDColumn,
+		Name:         NameColumn,
+		LibraryType:  LibraryTypeColumn,
+		Created:      CreatedColumn,
+		Modified:     ModifiedColumn,
+		GhostID:      GhostIDColumn,
+		ScanSettings: ScanSettingsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -23,6 +23,7 @@ type libraryPathTable struct {
 	Created   postgres.ColumnTimestamp
 	Modified  postgres.ColumnTimestamp
 	GhostID   postgres.ColumnInteger
+	Watch     postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -69,8 +70,9 @@ func newLibraryPathTableImpl(schemaName, tableName, alias string) libraryPathTab
 		CreatedColumn   = postgres.TimestampColumn("created")
 		ModifiedColumn  = postgres.TimestampColumn("modified")
 		GhostIDColumn   = postgres.IntegerColumn("ghost_id")
-		allColumns      = postgres.ColumnList{IDColumn, LibraryIDColumn, PathColumn, CreatedColumn, ModifiedColumn, GhostIDColumn}
-		mutableColumns  = postgres.ColumnList{LibraryIDColumn, PathColumn, CreatedColumn, ModifiedColumn, GhostIDColumn}
+		WatchColumn     = postgres.StringColumn("watch")
+		allColumns      = postgres.ColumnList{IDColumn, LibraryIDColumn, PathColumn, CreatedColumn, ModifiedColumn, GhostIDColumn, WatchColumn}
+		mutableColumns  = postgres.ColumnList{LibraryIDColumn, PathColumn, CreatedColumn, ModifiedColumn, GhostIDColumn, WatchColumn}
 	)
 
 	return libraryPathTable{
@@ -83,6 +85,7 @@ func newLibraryPathTableImpl(schemaName, tableName, alias string) libraryPathTab
 		Created:   CreatedColumn,
 		Modified:  ModifiedColumn,
 		GhostID:   GhostIDColumn,
+		Watch:     WatchColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
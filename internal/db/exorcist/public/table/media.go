@@ -17,19 +17,23 @@ type mediaTable struct {
 	postgres.Table
 
 	// Columns
-	ID            postgres.ColumnString
-	LibraryPathID postgres.ColumnString
-	Path          postgres.ColumnString
-	Title         postgres.ColumnString
-	MediaType     postgres.ColumnString
-	Size          postgres.ColumnInteger
-	Checksum      postgres.ColumnString
-	Added         postgres.ColumnTimestamp
-	Deleted       postgres.ColumnBool
-	Exists        postgres.ColumnBool
-	Created       postgres.ColumnTimestamp
-	Modified      postgres.ColumnTimestamp
-	GhostID       postgres.ColumnInteger
+	ID                       postgres.ColumnString
+	LibraryPathID            postgres.ColumnString
+	Path                     postgres.ColumnString
+	Title                    postgres.ColumnString
+	MediaType                postgres.ColumnString
+	Size                     postgres.ColumnInteger
+	Checksum                 postgres.ColumnString
+	Added                    postgres.ColumnTimestamp
+	Deleted                  postgres.ColumnBool
+	Exists                   postgres.ColumnBool
+	Created                  postgres.ColumnTimestamp
+	Modified                 postgres.ColumnTimestamp
+	GhostID                  postgres.ColumnInteger
+	FileModified             postgres.ColumnTimestamp
+	ChecksumAlgorithm        postgres.ColumnString
+	PartialChecksum          postgres.ColumnString
+	PartialChecksumAlgorithm postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -70,40 +74,48 @@ func newMediaTable(schemaName, tableName, alias string) *MediaTable {
 
 func newMediaTableImpl(schemaName, tableName, alias string) mediaTable {
 	var (
-		IDColumn            = postgres.StringColumn("id")
-		LibraryPathIDColumn = postgres.StringColumn("library_path_id")
-		PathColumn          = postgres.StringColumn("path")
-		TitleColumn         = postgres.StringColumn("title")
-		MediaTypeColumn     = postgres.StringColumn("media_type")
-		SizeColumn          = postgres.IntegerColumn("size")
-		ChecksumColumn      = postgres.StringColumn("checksum")
-		AddedColumn         = postgres.TimestampColumn("added")
-		DeletedColumn       = postgres.BoolColumn("deleted")
-		ExistsColumn        = postgres.BoolColumn("exists")
-		CreatedColumn       = postgres.TimestampColumn("created")
-		ModifiedColumn      = postgres.TimestampColumn("modified")
-		GhostIDColumn       = postgres.IntegerColumn("ghost_id")
-		allColumns          = postgres.ColumnList{IDColumn, LibraryPathIDColumn, PathColumn, TitleColumn, MediaTypeColumn, SizeColumn, ChecksumColumn, AddedColumn, DeletedColumn, ExistsColumn, CreatedColumn, ModifiedColumn, GhostIDColumn}
-		mutableColumns      = postgres.ColumnList{LibraryPathIDColumn, PathColumn, TitleColumn, MediaTypeColumn, SizeColumn, ChecksumColumn, AddedColumn, DeletedColumn, ExistsColumn, CreatedColumn, ModifiedColumn, GhostIDColumn}
+		IDColumn                       = postgres.StringColumn("id")
+		LibraryPathIDColumn            = postgres.StringColumn("library_path_id")
+		PathColumn                     = postgres.StringColumn("path")
+		TitleColumn                    = postgres.StringColumn("title")
+		MediaTypeColumn                = postgres.StringColumn("media_type")
+		SizeColumn                     = postgres.IntegerColumn("size")
+		ChecksumColumn                 = postgres.StringColumn("checksum")
+		AddedColumn                    = postgres.TimestampColumn("added")
+		DeletedColumn                  = postgres.BoolColumn("deleted")
+		ExistsColumn                   = postgres.BoolColumn("exists")
+		CreatedColumn                  = postgres.TimestampColumn("created")
+		ModifiedColumn                 = postgres.TimestampColumn("modified")
+		GhostIDColumn                  = postgres.IntegerColumn("ghost_id")
+		FileModifiedColumn             = postgres.TimestampColumn("file_modified")
+		ChecksumAlgorithmColumn        = postgres.StringColumn("checksum_algorithm")
+		PartialChecksumColumn          = postgres.StringColumn("partial_checksum")
+		PartialChecksumAlgorithmColumn = postgres.StringColumn("partial_checksum_algorithm")
+		allColumns                     = postgres.ColumnList{IDColumn, LibraryPathIDColumn, PathColumn, TitleColumn, MediaTypeColumn, SizeColumn, ChecksumColumn, AddedColumn, DeletedColumn, ExistsColumn, CreatedColumn, ModifiedColumn, GhostIDColumn, FileModifiedColumn, ChecksumAlgorithmColumn, PartialChecksumColumn, PartialChecksumAlgorithmColumn}
+		mutableColumns                 = postgres.ColumnList{LibraryPathIDColumn, PathColumn, TitleColumn, MediaTypeColumn, SizeColumn, ChecksumColumn, AddedColumn, DeletedColumn, ExistsColumn, CreatedColumn, ModifiedColumn, GhostIDColumn, FileModifiedColumn, ChecksumAlgorithmColumn, PartialChecksumColumn, PartialChecksumAlgorithmColumn}
 	)
 
 	return mediaTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:            IDColumn,
-		LibraryPathID: LibraryPathIDColumn,
-		Path:          PathColumn,
-		Title:         TitleColumn,
-		MediaType:     MediaTypeColumn,
-		Size:          SizeColumn,
-		Checksum:      ChecksumColumn,
-		Added:         AddedColumn,
-		Deleted:       DeletedColumn,
-		Exists:        ExistsColumn,
-		Created:       CreatedColumn,
-		Modified:      ModifiedColumn,
-		GhostID:       GhostIDColumn,
+		ID:                       IDColumn,
+		LibraryPathID:            LibraryPathIDColumn,
+		Path:                     PathColumn,
+		Title:                    TitleColumn,
+		MediaType:                MediaTypeColumn,
+		Size:                     SizeColumn,
+		Checksum:                 ChecksumColumn,
+		Added:                    AddedColumn,
+		Deleted:                  DeletedColumn,
+		Exists:                   ExistsColumn,
+		Created:                  CreatedColumn,
+		Modified:                 ModifiedColumn,
+		GhostID:                  GhostIDColumn,
+		FileModified:             FileModifiedColumn,
+		ChecksumAlgorithm:        ChecksumAlgorithmColumn,
+		PartialChecksum:          PartialChecksumColumn,
+		PartialChecksumAlgorithm: PartialChecksumAlgorithmColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -14,6 +14,8 @@ func UseSchema(schema string) {
 	FavouritePerson = FavouritePerson.FromSchema(schema)
 	Image = Image.FromSchema(schema)
 	Job = Job.FromSchema(schema)
+	JobLog = JobLog.FromSchema(schema)
+	JobSchedule = JobSchedule.FromSchema(schema)
 	Library = Library.FromSchema(schema)
 	LibraryPath = LibraryPath.FromSchema(schema)
 	Media = Media.FromSchema(schema)
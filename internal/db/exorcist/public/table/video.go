@@ -17,12 +17,13 @@ type videoTable struct {
 	postgres.Table
 
 	// Columns
-	ID      postgres.ColumnString
-	MediaID postgres.ColumnString
-	Height  postgres.ColumnInteger
-	Width   postgres.ColumnInteger
-	Runtime postgres.ColumnFloat
-	GhostID postgres.ColumnInteger
+	ID          postgres.ColumnString
+	MediaID     postgres.ColumnString
+	Height      postgres.ColumnInteger
+	Width       postgres.ColumnInteger
+	Runtime     postgres.ColumnFloat
+	GhostID     postgres.ColumnInteger
+	Fingerprint postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +64,28 @@ func newVideoTable(schemaName, tableName, alias string) *VideoTable {
 
 func newVideoTableImpl(schemaName, tableName, alias string) videoTable {
 	var (
-		IDColumn       = postgres.StringColumn("id")
-		MediaIDColumn  = postgres.StringColumn("media_id")
-		HeightColumn   = postgres.IntegerColumn("height")
-		WidthColumn    = postgres.IntegerColumn("width")
-		RuntimeColumn  = postgres.FloatColumn("runtime")
-		GhostIDColumn  = postgres.IntegerColumn("ghost_id")
-		allColumns     = postgres.ColumnList{IDColumn, MediaIDColumn, HeightColumn, WidthColumn, RuntimeColumn, GhostIDColumn}
-		mutableColumns = postgres.ColumnList{MediaIDColumn, HeightColumn, WidthColumn, RuntimeColumn, GhostIDColumn}
+		IDColumn          = postgres.StringColumn("id")
+		MediaIDColumn     = postgres.StringColumn("media_id")
+		HeightColumn      = postgres.IntegerColumn("height")
+		WidthColumn       = postgres.IntegerColumn("width")
+		RuntimeColumn     = postgres.FloatColumn("runtime")
+		GhostIDColumn     = postgres.IntegerColumn("ghost_id")
+		FingerprintColumn = postgres.StringColumn("fingerprint")
+		allColumns        = postgres.ColumnList{IDColumn, MediaIDColumn, HeightColumn, WidthColumn, RuntimeColumn, GhostIDColumn, FingerprintColumn}
+		mutableColumns    = postgres.ColumnList{MediaIDColumn, HeightColumn, WidthColumn, RuntimeColumn, GhostIDColumn, FingerprintColumn}
 	)
 
 	return videoTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:      IDColumn,
-		MediaID: MediaIDColumn,
-		Height:  HeightColumn,
-		Width:   WidthColumn,
-		Runtime: RuntimeColumn,
-		GhostID: GhostIDColumn,
+		ID:          IDColumn,
+		MediaID:     MediaIDColumn,
+		Height:      HeightColumn,
+		Width:       WidthColumn,
+		Runtime:     RuntimeColumn,
+		GhostID:     GhostIDColumn,
+		Fingerprint: FingerprintColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -0,0 +1,124 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/models"
+)
+
+// DuplicateKey is what media is compared by to find duplicates
+type DuplicateKey string
+
+const (
+	DuplicateKey_Checksum        DuplicateKey = "checksum"
+	DuplicateKey_PartialChecksum DuplicateKey = "partial_checksum"
+	// DuplicateKey_SizeRuntime compares the size and runtime of videos and the size and dimensions of images
+	DuplicateKey_SizeRuntime DuplicateKey = "size_runtime"
+)
+
+var DuplicateKeyAllValues = []DuplicateKey{
+	DuplicateKey_Checksum,
+	DuplicateKey_PartialChecksum,
+	DuplicateKey_SizeRuntime,
+}
+
+func (k DuplicateKey) String() string {
+	return string(k)
+}
+
+type DuplicateSearchDTO struct {
+	By DuplicateKey `form:"by" json:"by" binding:"omitempty,oneof=checksum partial_checksum size_runtime"`
+}
+
+type DuplicateGroupDTO struct {
+	Checksum          *string                      `json:"checksum,omitempty"`
+	ChecksumAlgorithm *model.ChecksumAlgorithmEnum `json:"checksumAlgorithm,omitempty"`
+	PartialChecksum   *string                      `json:"partialChecksum,omitempty"`
+	Size              int64                        `json:"size"`
+	Runtime           float64                      `json:"runtime,omitempty"`
+	Media             []DuplicateMediaDTO          `json:"media"`
+}
+
+// FromModel expects the media of the group to have the same checksum, partial checksum or size and runtime
+func (d *DuplicateGroupDTO) FromModel(group []models.DuplicateMedia) *DuplicateGroupDTO {
+	d.Media = make([]DuplicateMediaDTO, len(group))
+	for i, m := range group {
+		d.Media[i] = *(&DuplicateMediaDTO{}).FromModel(m)
+	}
+
+	if len(group) > 0 {
+		d.Checksum = group[0].Checksum
+		d.ChecksumAlgorithm = group[0].ChecksumAlgorithm
+		d.PartialChecksum = group[0].PartialChecksum
+		d.Size = group[0].Size
+		d.Runtime = d.Media[0].Runtime
+	}
+
+	return d
+}
+
+type DuplicateMediaDTO struct {
+	Id          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Path        string    `json:"path"`
+	LibraryId   uuid.UUID `json:"libraryId"`
+	LibraryName string    `json:"libraryName"`
+	Width       int32     `json:"width"`
+	Height      int32     `json:"height"`
+	Runtime     float64   `json:"runtime,omitempty"`
+	Size        int64     `json:"size"`
+	Added       time.Time `json:"added"`
+}
+
+func (d *DuplicateMediaDTO) FromModel(m models.DuplicateMedia) *DuplicateMediaDTO {
+	d.Id = m.Media.ID
+	d.Title = m.Title
+	d.Path = m.Path
+	d.LibraryId = m.Library.ID
+	d.LibraryName = m.Library.Name
+	d.Size = m.Size
+	d.Added = m.Added
+
+	if m.Video != nil {
+		d.Width = m.Video.Width
+		d.Height = m.Video.Height
+		d.Runtime = m.Video.Runtime
+	} else if m.Image != nil {
+		d.Width = m.Image.Width
+		d.Height = m.Image.Height
+	}
+
+	return d
+}
+
+// ResolveDuplicatesDTO keeps one of a group of duplicates and deletes the others.
+// Tags, people, favourites and progress of the deleted media are merged into the media that is kept
+type ResolveDuplicatesDTO struct {
+	Keep     uuid.UUID   `json:"keep" binding:"required"`
+	Remove   []uuid.UUID `json:"remove" binding:"required,min=1"`
+	Physical bool        `json:"physical"`
+}
+
+// DefaultNearDuplicateThreshold is the similarity that fingerprints need when no threshold is given
+const DefaultNearDuplicateThreshold = 0.9
+
+type NearDuplicateSearchDTO struct {
+	Threshold float64 `form:"threshold" json:"threshold" binding:"omitempty,gt=0,lte=1"`
+}
+
+// NearDuplicateDTO is a pair of videos that look alike but do not have to be the same file
+type NearDuplicateDTO struct {
+	A          DuplicateMediaDTO `json:"a"`
+	B          DuplicateMediaDTO `json:"b"`
+	Similarity float64           `json:"similarity"`
+}
+
+func (d *NearDuplicateDTO) FromModel(m models.NearDuplicate) *NearDuplicateDTO {
+	d.A = *(&DuplicateMediaDTO{}).FromModel(m.A)
+	d.B = *(&DuplicateMediaDTO{}).FromModel(m.B)
+	d.Similarity = m.Similarity
+
+	return d
+}
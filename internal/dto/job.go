@@ -7,11 +7,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/models"
 )
 
 type CreateJobDTO struct {
 	Type     model.JobTypeEnum      `json:"type" binding:"required" tstype:"model.JobTypeEnum"`
-	Data     map[string]interface{} `json:"data" tstype:"ScanPathData | GenerateThumbnailData"`
+	Data     map[string]interface{} `json:"data" tstype:"JobData"`
 	Priority *JobPriority           `json:"priority"`
 }
 
@@ -76,6 +77,12 @@ type JobDTO struct {
 	Outcome  *string             `json:"outcome,omitempty"`
 	Created  time.Time           `json:"created,omitempty"`
 	Modified time.Time           `json:"modified,omitempty"`
+	Attempts int16               `json:"attempts"`
+	RunAfter time.Time           `json:"runAfter,omitempty"`
+	Started  *time.Time          `json:"started,omitempty"`
+	Progress *JobProgressDTO     `json:"progress,omitempty"`
+	// EffectivePriority is the priority after aging. Only reported when listing jobs
+	EffectivePriority *int16 `json:"effectivePriority,omitempty"`
 }
 
 func (j *JobDTO) FromModel(m model.Job) *JobDTO {
@@ -88,6 +95,122 @@ func (j *JobDTO) FromModel(m model.Job) *JobDTO {
 	j.Outcome = m.Outcome
 	j.Created = m.Created
 	j.Modified = m.Modified
+	j.Attempts = m.Attempts
+	j.RunAfter = m.RunAfter
+	j.Started = m.Started
+	if m.ProgressTotal > 0 {
+		j.Progress = (&JobProgressDTO{}).FromModel(m, time.Now())
+	}
+
+	return j
+}
+
+func (j *JobDTO) FromOverviewModel(m models.JobOverviewModel) *JobDTO {
+	j.FromModel(m.Job)
+	j.EffectivePriority = &m.EffectivePriority
+
+	return j
+}
+
+type JobProgressDTO struct {
+	Id         uuid.UUID `json:"id"`
+	Processed  int32     `json:"processed"`
+	Total      int32     `json:"total"`
+	Percentage float64   `json:"percentage"`
+	Message    *string   `json:"message,omitempty"`
+	// Eta is the estimated number of seconds until the job is done
+	Eta *float64 `json:"eta,omitempty"`
+}
+
+// FromModel calculates the percentage and the estimated time remaining based on how long the job has been running
+func (j *JobProgressDTO) FromModel(m model.Job, now time.Time) *JobProgressDTO {
+	j.Id = m.ID
+	j.Processed = m.ProgressProcessed
+	j.Total = m.ProgressTotal
+	j.Message = m.ProgressMessage
+
+	if m.ProgressTotal > 0 {
+		j.Percentage = float64(m.ProgressProcessed) / float64(m.ProgressTotal) * 100
+	}
+
+	if m.Started != nil && m.ProgressProcessed > 0 && m.ProgressProcessed <= m.ProgressTotal {
+		elapsed := now.Sub(*m.Started).Seconds()
+		eta := elapsed / float64(m.ProgressProcessed) * float64(m.ProgressTotal-m.ProgressProcessed)
+		j.Eta = &eta
+	}
 
 	return j
 }
+
+type JobTreeStatus string
+
+const (
+	JobTreeStatus_NotStarted          JobTreeStatus = "not_started"
+	JobTreeStatus_InProgress          JobTreeStatus = "in_progress"
+	JobTreeStatus_Completed           JobTreeStatus = "completed"
+	JobTreeStatus_CompletedWithErrors JobTreeStatus = "completed_with_errors"
+	JobTreeStatus_Failed              JobTreeStatus = "failed"
+	JobTreeStatus_Cancelled           JobTreeStatus = "cancelled"
+)
+
+var JobTreeStatusAllValues = []JobTreeStatus{
+	JobTreeStatus_NotStarted,
+	JobTreeStatus_InProgress,
+	JobTreeStatus_Completed,
+	JobTreeStatus_CompletedWithErrors,
+	JobTreeStatus_Failed,
+	JobTreeStatus_Cancelled,
+}
+
+func (s JobTreeStatus) String() string {
+	return string(s)
+}
+
+// RollupJobStatus derives the status of a job from its own status and the statuses of its descendants.
+// A completed job is still in progress while any of its descendants are, and completed with errors when any of them failed or were cancelled
+func RollupJobStatus(status model.JobStatusEnum, descendants map[model.JobStatusEnum]int) JobTreeStatus {
+	switch status {
+	case model.JobStatusEnum_NotStarted:
+		return JobTreeStatus_NotStarted
+	case model.JobStatusEnum_InProgress:
+		return JobTreeStatus_InProgress
+	case model.JobStatusEnum_Failed:
+		return JobTreeStatus_Failed
+	case model.JobStatusEnum_Cancelled:
+		return JobTreeStatus_Cancelled
+	}
+
+	if descendants[model.JobStatusEnum_NotStarted]+descendants[model.JobStatusEnum_InProgress] > 0 {
+		return JobTreeStatus_InProgress
+	}
+
+	if descendants[model.JobStatusEnum_Failed]+descendants[model.JobStatusEnum_Cancelled] > 0 {
+		return JobTreeStatus_CompletedWithErrors
+	}
+
+	return JobTreeStatus_Completed
+}
+
+type JobTreeDTO struct {
+	Job         JobDTO                      `json:"job"`
+	Status      JobTreeStatus               `json:"status"`
+	Done        bool                        `json:"done"`
+	Total       int                         `json:"total"`
+	Descendants map[model.JobStatusEnum]int `json:"descendants" tstype:"Partial<Record<model.JobStatusEnum, number>>"`
+}
+
+func (t *JobTreeDTO) FromModel(m models.JobTree) *JobTreeDTO {
+	t.Job = *(&JobDTO{}).FromModel(m.Job)
+	t.Status = RollupJobStatus(m.Job.Status, m.Descendants)
+	t.Descendants = m.Descendants
+
+	t.Total = 0
+	for _, c := range m.Descendants {
+		t.Total = t.Total + c
+	}
+
+	pending := m.Descendants[model.JobStatusEnum_NotStarted] + m.Descendants[model.JobStatusEnum_InProgress]
+	t.Done = pending == 0 && t.Status != JobTreeStatus_NotStarted && t.Status != JobTreeStatus_InProgress
+
+	return t
+}
@@ -7,6 +7,68 @@ import (
 
 type ScanPathData struct {
 	LibraryPathId uuid.UUID `json:"libraryPathId"`
+	// Optional: Walks and probes the library path without writing anything. The report is stored in the job outcome
+	DryRun bool `json:"dryRun,omitempty"`
+	// Optional: Only scans these files and directories of the library path instead of walking all of it
+	Paths []string `json:"paths,omitempty"`
+	// Optional: Probes every file again instead of only the files that are new or of which the size or modification time changed
+	Full bool `json:"full,omitempty"`
+}
+
+// ScanPathReport is the outcome of a dry run of a scan path job
+type ScanPathReport struct {
+	New           []ScanPathReportFile    `json:"new"`
+	Changed       []ScanPathReportFile    `json:"changed"`
+	Missing       []ScanPathReportMedia   `json:"missing"`
+	Moved         []ScanPathReportMove    `json:"moved"`
+	ProbeFailures []ScanPathReportFailure `json:"probeFailures"`
+	Ignored       []ScanPathReportIgnore  `json:"ignored"`
+}
+
+// ScanPathReportFile is a file that would be added as media or of which the media would be updated
+type ScanPathReportFile struct {
+	Path    string  `json:"path"`
+	Size    int64   `json:"size"`
+	Width   int     `json:"width"`
+	Height  int     `json:"height"`
+	Runtime float64 `json:"runtime"`
+}
+
+// ScanPathReportMedia is existing media that would be marked as not existing
+type ScanPathReportMedia struct {
+	Id   uuid.UUID `json:"id"`
+	Path string    `json:"path"`
+}
+
+// ScanPathReportMove is existing media of which the file would be updated to a new file with the same size and checksum
+type ScanPathReportMove struct {
+	Id   uuid.UUID `json:"id"`
+	From string    `json:"from"`
+	To   string    `json:"to"`
+}
+
+// ScanPathReportFailure is a file that could not be probed
+type ScanPathReportFailure struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// ScanPathReportIgnore is an ignore file and the number of files that it excluded from the scan
+type ScanPathReportIgnore struct {
+	Path  string `json:"path"`
+	Files int    `json:"files"`
+}
+
+type ScanLibraryData struct {
+	LibraryId uuid.UUID `json:"libraryId"`
+}
+
+// GenerateChecksumData calculates the partial checksum of the media unless Full is set
+type GenerateChecksumData struct {
+	MediaId uuid.UUID `json:"mediaId"`
+	Full    bool      `json:"full,omitempty"`
+	// Optional: If not set, the configured algorithm will be used
+	Algorithm *model.ChecksumAlgorithmEnum `json:"algorithm,omitempty"`
 }
 
 type GenerateThumbnailData struct {
@@ -47,3 +109,22 @@ type GenerateChaptersData struct {
 	Metadata     *ChapterMetadadataDTO `json:"metadata"`
 	Overwrite    bool                  `json:"overwrite"`
 }
+
+type GenerateLibraryChaptersData struct {
+	LibraryId    uuid.UUID `json:"libraryId"`
+	BatchSize    int       `json:"batchSize"`
+	Interval     float64   `json:"interval"`
+	MaxDimension int       `json:"maxDimension"`
+	Overwrite    bool      `json:"overwrite"`
+}
+
+type GenerateFingerprintData struct {
+	MediaId   uuid.UUID `json:"mediaId"`
+	Overwrite bool      `json:"overwrite"`
+}
+
+type GenerateLibraryFingerprintsData struct {
+	LibraryId uuid.UUID `json:"libraryId"`
+	BatchSize int       `json:"batchSize"`
+	Overwrite bool      `json:"overwrite"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+)
+
+type JobLogSearchDTO struct {
+	PageRequestDTO
+	Levels []model.JobLogLevelEnum `form:"level" json:"levels" tstype:"model.JobLogLevelEnum"`
+}
+
+type JobLogDTO struct {
+	Id      uuid.UUID             `json:"id"`
+	JobId   uuid.UUID             `json:"jobId"`
+	Level   model.JobLogLevelEnum `json:"level" tstype:"model.JobLogLevelEnum"`
+	Message string                `json:"message"`
+	Created time.Time             `json:"created"`
+}
+
+func (l *JobLogDTO) FromModel(m model.JobLog) *JobLogDTO {
+	l.Id = m.ID
+	l.JobId = m.JobID
+	l.Level = m.Level
+	l.Message = m.Message
+	l.Created = m.Created
+
+	return l
+}
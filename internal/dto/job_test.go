@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RollupJobStatus_CompletedWithPendingDescendants_ShouldBeInProgress(t *testing.T) {
+	descendants := map[model.JobStatusEnum]int{
+		model.JobStatusEnum_Completed:  3,
+		model.JobStatusEnum_NotStarted: 1,
+	}
+
+	assert.Equal(t, JobTreeStatus_InProgress, RollupJobStatus(model.JobStatusEnum_Completed, descendants))
+}
+
+func Test_RollupJobStatus_CompletedWithFailedDescendants_ShouldBeCompletedWithErrors(t *testing.T) {
+	descendants := map[model.JobStatusEnum]int{
+		model.JobStatusEnum_Completed: 3,
+		model.JobStatusEnum_Failed:    1,
+	}
+
+	assert.Equal(t, JobTreeStatus_CompletedWithErrors, RollupJobStatus(model.JobStatusEnum_Completed, descendants))
+}
+
+func Test_RollupJobStatus_CompletedWithCompletedDescendants_ShouldBeCompleted(t *testing.T) {
+	descendants := map[model.JobStatusEnum]int{
+		model.JobStatusEnum_Completed: 3,
+	}
+
+	assert.Equal(t, JobTreeStatus_Completed, RollupJobStatus(model.JobStatusEnum_Completed, descendants))
+}
+
+func Test_RollupJobStatus_Failed_ShouldBeFailed(t *testing.T) {
+	assert.Equal(t, JobTreeStatus_Failed, RollupJobStatus(model.JobStatusEnum_Failed, map[model.JobStatusEnum]int{}))
+}
+
+func Test_JobTreeDTO_FromModel_WithPendingDescendants_ShouldNotBeDone(t *testing.T) {
+	tree := models.JobTree{
+		Job: model.Job{Status: model.JobStatusEnum_Completed},
+		Descendants: map[model.JobStatusEnum]int{
+			model.JobStatusEnum_Completed:  2,
+			model.JobStatusEnum_InProgress: 1,
+		},
+	}
+
+	actual := (&JobTreeDTO{}).FromModel(tree)
+
+	assert.False(t, actual.Done)
+	assert.Equal(t, 3, actual.Total)
+}
@@ -1,10 +1,12 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	errs "github.com/slugger7/exorcist/internal/errors"
 )
 
 type CreateLibraryDTO struct {
@@ -12,21 +14,48 @@ type CreateLibraryDTO struct {
 }
 
 type LibraryDTO struct {
-	Id       uuid.UUID `json:"id,omitempty"`
-	Name     string    `json:"name,omitempty"`
-	Created  time.Time `json:"created,omitempty"`
-	Modified time.Time `json:"modified,omitempty"`
+	Id           uuid.UUID               `json:"id,omitempty"`
+	Name         string                  `json:"name,omitempty"`
+	ScanSettings *LibraryScanSettingsDTO `json:"scanSettings,omitempty"`
+	Created      time.Time               `json:"created,omitempty"`
+	Modified     time.Time               `json:"modified,omitempty"`
 }
 
 func (l *LibraryDTO) FromModel(m model.Library) *LibraryDTO {
 	l.Id = m.ID
 	l.Name = m.Name
+	// scan settings that can not be parsed are left out here and fail the scans of the library instead
+	l.ScanSettings, _ = (&LibraryScanSettingsDTO{}).FromModel(m)
 	l.Created = m.Created
 	l.Modified = m.Modified
 
 	return l
 }
 
+// LibraryUpdateDTO only updates the fields that are set
 type LibraryUpdateDTO struct {
-	Name string `json:"name"`
+	Name         string                  `json:"name"`
+	ScanSettings *LibraryScanSettingsDTO `json:"scanSettings"`
+}
+
+// LibraryScanSettingsDTO sets which files scans of the paths of a library pick up.
+// Extensions that are not set fall back to the defaults and an empty list does not pick up any files of that kind.
+// Exclude has gitignore style patterns relative to each library path
+type LibraryScanSettingsDTO struct {
+	VideoExtensions []string `json:"videoExtensions" binding:"omitempty,dive,startswith=."`
+	ImageExtensions []string `json:"imageExtensions" binding:"omitempty,dive,startswith=."`
+	Exclude         []string `json:"exclude"`
+}
+
+// FromModel returns nil when the library does not have scan settings
+func (s *LibraryScanSettingsDTO) FromModel(m model.Library) (*LibraryScanSettingsDTO, error) {
+	if m.ScanSettings == nil {
+		return nil, nil
+	}
+
+	if err := json.Unmarshal([]byte(*m.ScanSettings), s); err != nil {
+		return nil, errs.BuildError(err, "could not unmarshal scan settings of library %v", m.ID)
+	}
+
+	return s, nil
 }
@@ -12,18 +12,26 @@ type CreateLibraryPathModelDTO struct {
 	Path      string    `json:"path" binding:"required"`
 }
 
+// UpdateLibraryPathDTO sets how a library path is watched for changes.
+// Notify falls back to polling when file system notifications are not available
+type UpdateLibraryPathDTO struct {
+	Watch model.WatchModeEnum `json:"watch" binding:"required,oneof=off notify poll" tstype:"model.WatchModeEnum"`
+}
+
 type LibraryPathDTO struct {
-	Id        uuid.UUID `json:"id,omitempty"`
-	LibraryId uuid.UUID `json:"libraryId,omitempty"`
-	Path      string    `json:"path,omitempty"`
-	Created   time.Time `json:"created"`
-	Modified  time.Time `json:"modified"`
+	Id        uuid.UUID           `json:"id,omitempty"`
+	LibraryId uuid.UUID           `json:"libraryId,omitempty"`
+	Path      string              `json:"path,omitempty"`
+	Watch     model.WatchModeEnum `json:"watch,omitempty" tstype:"model.WatchModeEnum"`
+	Created   time.Time           `json:"created"`
+	Modified  time.Time           `json:"modified"`
 }
 
 func (l *LibraryPathDTO) FromModel(m model.LibraryPath) *LibraryPathDTO {
 	l.Id = m.ID
 	l.LibraryId = m.LibraryID
 	l.Path = m.Path
+	l.Watch = m.Watch
 	l.Created = m.Created
 	l.Modified = m.Modified
 
@@ -110,25 +110,27 @@ func (v *MediaOverviewDTO) FromModel(m models.MediaOverviewModel) *MediaOverview
 }
 
 type MediaDTO struct {
-	ID            uuid.UUID    `json:"id"`
-	LibraryPathID uuid.UUID    `json:"libraryPathId"`
-	Path          string       `json:"path"`
-	Title         string       `json:"title"`
-	Size          int64        `json:"size"`
-	Checksum      *string      `json:"checksum"`
-	Exists        bool         `json:"exists"`
-	Deleted       bool         `json:"deleted"`
-	Added         time.Time    `json:"added"`
-	Created       time.Time    `json:"created"`
-	Modified      time.Time    `json:"modified"`
-	Image         *ImageDTO    `json:"image,omitempty"`
-	Video         *VideoDTO    `json:"video,omitempty"`
-	ThumbnailID   uuid.UUID    `json:"thumbnailId,omitempty"`
-	Progress      float64      `json:"progress"`
-	People        []PersonDTO  `json:"people"`
-	Tags          []TagDTO     `json:"tags"`
-	Favourite     bool         `json:"favourite"`
-	Chapters      []ChapterDTO `json:"chapters"`
+	ID                uuid.UUID                    `json:"id"`
+	LibraryPathID     uuid.UUID                    `json:"libraryPathId"`
+	Path              string                       `json:"path"`
+	Title             string                       `json:"title"`
+	Size              int64                        `json:"size"`
+	Checksum          *string                      `json:"checksum"`
+	ChecksumAlgorithm *model.ChecksumAlgorithmEnum `json:"checksumAlgorithm"`
+	PartialChecksum   *string                      `json:"partialChecksum"`
+	Exists            bool                         `json:"exists"`
+	Deleted           bool                         `json:"deleted"`
+	Added             time.Time                    `json:"added"`
+	Created           time.Time                    `json:"created"`
+	Modified          time.Time                    `json:"modified"`
+	Image             *ImageDTO                    `json:"image,omitempty"`
+	Video             *VideoDTO                    `json:"video,omitempty"`
+	ThumbnailID       uuid.UUID                    `json:"thumbnailId,omitempty"`
+	Progress          float64                      `json:"progress"`
+	People            []PersonDTO                  `json:"people"`
+	Tags              []TagDTO                     `json:"tags"`
+	Favourite         bool                         `json:"favourite"`
+	Chapters          []ChapterDTO                 `json:"chapters"`
 }
 
 func (d *MediaDTO) FromModel(m models.Media) *MediaDTO {
@@ -138,6 +140,8 @@ func (d *MediaDTO) FromModel(m models.Media) *MediaDTO {
 	d.Title = m.Title
 	d.Size = m.Size
 	d.Checksum = m.Checksum
+	d.ChecksumAlgorithm = m.ChecksumAlgorithm
+	d.PartialChecksum = m.PartialChecksum
 	d.Deleted = m.Deleted
 	d.Exists = m.Exists
 	d.Added = m.Added
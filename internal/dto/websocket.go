@@ -10,6 +10,7 @@ type WSTopic string
 const (
 	WSTopic_JobUpdate           WSTopic = "job_update"
 	WSTopic_JobCreate           WSTopic = "job_create"
+	WSTopic_JobProgress         WSTopic = "job_progress"
 	WSTopic_MediaUpdate         WSTopic = "media_update"
 	WSTopic_MediaOverviewUpdate WSTopic = "media_overview_update"
 	WSTopic_MediaCreate         WSTopic = "media_create"
@@ -19,6 +20,7 @@ const (
 var WSTopicAllValues = []WSTopic{
 	WSTopic_JobUpdate,
 	WSTopic_JobCreate,
+	WSTopic_JobProgress,
 	WSTopic_MediaUpdate,
 	WSTopic_MediaOverviewUpdate,
 	WSTopic_MediaCreate,
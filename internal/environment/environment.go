@@ -1,6 +1,7 @@
 package environment
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
@@ -36,6 +37,21 @@ type EnvironmentVariables struct {
 	Web                        *string
 	JobRunner                  bool
 	DisableJobs                []model.JobTypeEnum
+	JobWorkers                 int
+	JobConcurrency             map[model.JobTypeEnum]int
+	JobMaxAttempts             map[model.JobTypeEnum]int
+	JobRetryDelay              int
+	JobPriorityAging           int
+	JobPollInterval            int
+	JobHeartbeatInterval       int
+	JobHeartbeatTimeout        int
+	WorkerId                   string
+	Watcher                    bool
+	WatchPollInterval          int
+	WatchDebounce              int
+	ScanWorkers                int
+	ChecksumAlgorithm          model.ChecksumAlgorithmEnum
+	FullChecksum               bool
 	CorsOrigins                []string
 	WebsocketHeartbeatInterval int
 	MigrationPath              string
@@ -59,6 +75,21 @@ const (
 	WEB                          OsEnv = "WEB"
 	JOB_RUNNER                   OsEnv = "JOB_RUNNER"
 	DISABLE_JOBS                 OsEnv = "DISABLE_JOBS"
+	JOB_WORKERS                  OsEnv = "JOB_WORKERS"
+	JOB_CONCURRENCY              OsEnv = "JOB_CONCURRENCY"
+	JOB_MAX_ATTEMPTS             OsEnv = "JOB_MAX_ATTEMPTS"
+	JOB_RETRY_DELAY              OsEnv = "JOB_RETRY_DELAY"
+	JOB_PRIORITY_AGING           OsEnv = "JOB_PRIORITY_AGING"
+	JOB_POLL_INTERVAL            OsEnv = "JOB_POLL_INTERVAL"
+	JOB_HEARTBEAT_INTERVAL       OsEnv = "JOB_HEARTBEAT_INTERVAL"
+	JOB_HEARTBEAT_TIMEOUT        OsEnv = "JOB_HEARTBEAT_TIMEOUT"
+	WORKER_ID                    OsEnv = "WORKER_ID"
+	WATCHER                      OsEnv = "WATCHER"
+	WATCH_POLL_INTERVAL          OsEnv = "WATCH_POLL_INTERVAL"
+	WATCH_DEBOUNCE               OsEnv = "WATCH_DEBOUNCE"
+	SCAN_WORKERS                 OsEnv = "SCAN_WORKERS"
+	CHECKSUM_ALGORITHM           OsEnv = "CHECKSUM_ALGORITHM"
+	FULL_CHECKSUM                OsEnv = "FULL_CHECKSUM"
 	CORS_ORIGINS                 OsEnv = "CORS_ORIGINS"
 	WEBSOCKET_HEARTBEAT_INTERVAL OsEnv = "WEBSOCKET_HEARTBEAT_INTERVAL"
 	MIGRATIONS_PATH              OsEnv = "MIGRATIONS_PATH"
@@ -92,12 +123,37 @@ func RefreshEnvironmentVariables() {
 		Web:                        getValueOrNil(WEB),
 		JobRunner:                  getBoolValue(JOB_RUNNER, true),
 		DisableJobs:                toJobTypes(strings.Split(os.Getenv(DISABLE_JOBS), ";")),
+		JobWorkers:                 getIntValueOrDefault(JOB_WORKERS, 1),
+		JobConcurrency:             toJobTypeLimits(os.Getenv(JOB_CONCURRENCY)),
+		JobMaxAttempts:             toJobTypeLimits(os.Getenv(JOB_MAX_ATTEMPTS)),
+		JobRetryDelay:              getIntValueOrDefault(JOB_RETRY_DELAY, 30),
+		JobPriorityAging:           getIntValueOrDefault(JOB_PRIORITY_AGING, 3600),
+		JobPollInterval:            getIntValueOrDefault(JOB_POLL_INTERVAL, 30),
+		JobHeartbeatInterval:       getIntValueOrDefault(JOB_HEARTBEAT_INTERVAL, 15),
+		JobHeartbeatTimeout:        getIntValueOrDefault(JOB_HEARTBEAT_TIMEOUT, 60),
+		WorkerId:                   getValueOrDefault(WORKER_ID, defaultWorkerId()),
+		Watcher:                    getBoolValue(WATCHER, true),
+		WatchPollInterval:          getIntValueOrDefault(WATCH_POLL_INTERVAL, 60),
+		WatchDebounce:              getIntValueOrDefault(WATCH_DEBOUNCE, 5),
+		ScanWorkers:                getIntValueOrDefault(SCAN_WORKERS, 4),
+		ChecksumAlgorithm:          toChecksumAlgorithm(os.Getenv(CHECKSUM_ALGORITHM)),
+		FullChecksum:               getBoolValue(FULL_CHECKSUM, true),
 		CorsOrigins:                strings.Split(os.Getenv(CORS_ORIGINS), ";"),
 		WebsocketHeartbeatInterval: getIntValue(WEBSOCKET_HEARTBEAT_INTERVAL),
 		MigrationPath:              getValueOrDefault(MIGRATIONS_PATH, "./migrations"),
 	}
 }
 
+// defaultWorkerId identifies the process by its host name and process id
+func defaultWorkerId() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "exorcist"
+	}
+
+	return fmt.Sprintf("%v-%v", hostname, os.Getpid())
+}
+
 func toJobTypes(strs []string) []model.JobTypeEnum {
 	types := make([]model.JobTypeEnum, len(strs))
 	for i, str := range strs {
@@ -109,6 +165,54 @@ func toJobTypes(strs []string) []model.JobTypeEnum {
 	return types
 }
 
+// toChecksumAlgorithm defaults to md5 so that checksums stay comparable with the ones calculated before the
+// algorithm could be chosen
+func toChecksumAlgorithm(value string) model.ChecksumAlgorithmEnum {
+	algorithm := model.ChecksumAlgorithmEnum_Md5
+	if value == "" {
+		return algorithm
+	}
+
+	if err := algorithm.Scan(value); err != nil {
+		log.Printf("Could not convert %v to checksum algorithm, using %v", value, model.ChecksumAlgorithmEnum_Md5)
+		return model.ChecksumAlgorithmEnum_Md5
+	}
+
+	return algorithm
+}
+
+// toJobTypeLimits parses a semicolon delimited list of job_type=limit pairs
+func toJobTypeLimits(value string) map[model.JobTypeEnum]int {
+	limits := map[model.JobTypeEnum]int{}
+	if value == "" {
+		return limits
+	}
+
+	for _, pair := range strings.Split(value, ";") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			log.Printf("Could not parse %v as job_type=limit", pair)
+			continue
+		}
+
+		var jobType model.JobTypeEnum
+		if err := jobType.Scan(strings.TrimSpace(parts[0])); err != nil {
+			log.Printf("Could not convert %v to job type enum", parts[0])
+			continue
+		}
+
+		limit, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || limit < 1 {
+			log.Printf("Invalid limit for %v: %v", jobType, parts[1])
+			continue
+		}
+
+		limits[jobType] = limit
+	}
+
+	return limits
+}
+
 func getValueOrNil(key OsEnv) *string {
 	val := os.Getenv(key)
 	if val == "" {
@@ -132,6 +236,16 @@ func getIntValue(key OsEnv) int {
 	return value
 }
 
+func getIntValueOrDefault(key OsEnv, defaultValue int) int {
+	rawValue := os.Getenv(key)
+	value, err := strconv.Atoi(rawValue)
+	if err != nil {
+		log.Printf("No value or invalid value found for %v setting to default '%v'\nValue was: %v", key, defaultValue, rawValue)
+		return defaultValue
+	}
+	return value
+}
+
 func getBoolValue(key OsEnv, defaultValue bool) bool {
 	rawValue := os.Getenv(key)
 	actualValue, err := strconv.ParseBool(rawValue)
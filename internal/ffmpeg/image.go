@@ -1,6 +1,7 @@
 package ffmpeg
 
 import (
+	"context"
 	"fmt"
 
 	errs "github.com/slugger7/exorcist/internal/errors"
@@ -21,7 +22,8 @@ func ScaleHeightByWidth(currentHeight, currentWidth, wantedWidth int) int {
 	return int(float32(currentHeight) / float32(currentWidth) * float32(wantedWidth))
 }
 
-func ImageAt(vid string, time float64, img string, width, height int) error {
+// ImageAt extracts a single frame from the video. The ffmpeg process is killed when the context is cancelled
+func ImageAt(ctx context.Context, vid string, time float64, img string, width, height int) error {
 	if width <= 0 {
 		return fmt.Errorf(ErrNegativeWidth, width)
 	}
@@ -29,9 +31,11 @@ func ImageAt(vid string, time float64, img string, width, height int) error {
 		return fmt.Errorf(ErrNegativeHeight, height)
 	}
 
-	err := ffmpeg_go.Input(vid, ffmpeg_go.KwArgs{"ss": time}).
-		Output(img, ffmpeg_go.KwArgs{"vframes": 1, "s": fmt.Sprintf("%vx%v", width, height)}).
-		Run()
+	stream := ffmpeg_go.Input(vid, ffmpeg_go.KwArgs{"ss": time}).
+		Output(img, ffmpeg_go.KwArgs{"vframes": 1, "s": fmt.Sprintf("%vx%v", width, height)})
+	stream.Context = ctx
+
+	err := stream.Run()
 
 	if err != nil {
 		return errs.BuildError(err, ErrExtractingImage, img, vid, time, width, height)
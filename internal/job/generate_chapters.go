@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,9 +13,64 @@ import (
 	"github.com/slugger7/exorcist/internal/dto"
 	errs "github.com/slugger7/exorcist/internal/errors"
 	"github.com/slugger7/exorcist/internal/ffmpeg"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/models"
+	"github.com/slugger7/exorcist/internal/repository"
 )
 
+// defaultChapterInterval is the number of seconds between chapters when no interval is given
+var defaultChapterInterval = (time.Minute * 5).Seconds()
+
+var generateChaptersJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.GenerateChaptersData]{
+	Type:        model.JobTypeEnum_GenerateChapters,
+	Priority:    dto.JobPriority_Medium,
+	MaxAttempts: 2,
+	Defaults: func(d *dto.GenerateChaptersData) {
+		if d.Interval == 0 {
+			d.Interval = defaultChapterInterval
+		}
+	},
+	Validate: func(repo repository.Repository, d dto.GenerateChaptersData) error {
+		media, err := validateMedia(repo, d.MediaId)
+		if err != nil {
+			return err
+		}
+
+		if media.Video == nil {
+			return fmt.Errorf("media is not of type video: %v", d.MediaId.String())
+		}
+
+		return nil
+	},
+	// chapters of a different interval or size or that overwrite the existing chapters are not the same work
+	DedupKey: func(d dto.GenerateChaptersData) *string {
+		parts := []any{d.MediaId, d.Interval, fmt.Sprintf("%vx%v", d.Height, d.Width), d.MaxDimension}
+		if d.Overwrite {
+			parts = append(parts, "overwrite")
+		}
+		return jobRegistry.Key(model.JobTypeEnum_GenerateChapters, parts...)
+	},
+	Run: (*JobRunner).generateChapters,
+})
+
+func CreateGenerateChaptersJob(mediaId uuid.UUID, jobId *uuid.UUID, interval float64, maxDimension int, overwrite bool, priority int16) (*model.Job, error) {
+	d := dto.GenerateChaptersData{
+		MediaId:      mediaId,
+		Interval:     interval,
+		MaxDimension: maxDimension,
+		Overwrite:    overwrite,
+	}
+
+	job, err := generateChaptersJob.NewJob(d, jobId)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not create generate chapters job for: %v", mediaId)
+	}
+
+	job.Priority = priority
+
+	return job, nil
+}
+
 func (jr *JobRunner) removeChapters(id uuid.UUID, chapters []models.MediaChapter) error {
 	var accErr error
 	for _, i := range chapters {
@@ -36,7 +92,7 @@ func (jr *JobRunner) removeChapters(id uuid.UUID, chapters []models.MediaChapter
 	return accErr
 }
 
-func (jr *JobRunner) generateChapters(job *model.Job) error {
+func (jr *JobRunner) generateChapters(ctx context.Context, job *model.Job) error {
 	var jobData dto.GenerateChaptersData
 	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
 		return errs.BuildError(err, "error parsing job data for generate chapters: %v", job.Data)
@@ -54,10 +110,10 @@ func (jr *JobRunner) generateChapters(job *model.Job) error {
 	if len(media.Chapters) > 0 {
 		if jobData.Overwrite {
 			if err := jr.removeChapters(media.Media.ID, media.Chapters); err != nil {
-				jr.logger.Warningf("some issues removing previous chapters: %v", err.Error())
+				jr.log(ctx).Warningf("some issues removing previous chapters: %v", err.Error())
 			}
 		} else {
-			jr.logger.Infof("chapters already exist for %v as it already has chapters and overwrite was set to false", jobData.MediaId)
+			jr.log(ctx).Infof("chapters already exist for %v as it already has chapters and overwrite was set to false", jobData.MediaId)
 			return nil
 		}
 	}
@@ -119,13 +175,16 @@ func (jr *JobRunner) generateChapters(job *model.Job) error {
 	}
 
 	if accErr != nil {
-		jr.logger.Errorf("encountered while creating generate thumbnail jobs: %v", accErr.Error())
+		jr.log(ctx).Errorf("encountered while creating generate thumbnail jobs: %v", accErr.Error())
 	}
 
 	if len(generateThumbnailJobs) != 0 {
-		if _, err := jr.repo.Job().CreateAll(generateThumbnailJobs); err != nil {
+		_, skipped, err := jr.repo.Job().CreateAll(generateThumbnailJobs)
+		if err != nil {
 			return errs.BuildError(err, "creating generate thumbnail jobs")
 		}
+
+		jr.logSkippedJobs(ctx, skipped)
 	}
 
 	return nil
@@ -0,0 +1,47 @@
+package job
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateGenerateChaptersJob(t *testing.T) {
+	mediaId, _ := uuid.NewRandom()
+	jobId, _ := uuid.NewRandom()
+
+	actual, err := CreateGenerateChaptersJob(mediaId, &jobId, 60, 400, true, dto.JobPriority_Medium)
+	assert.Nil(t, err)
+
+	actualData := *actual.Data
+	actual.Data = nil
+
+	expectedData := fmt.Sprintf(`{"mediaId":"%v","interval":60,"height":0,"width":0,"maxDimension":400,"metadata":null,"overwrite":true}`, mediaId)
+	expectedDedupKey := fmt.Sprintf("generate_chapters:%v:60:0x0:400:overwrite", mediaId)
+	expected := model.Job{
+		JobType:  model.JobTypeEnum_GenerateChapters,
+		Status:   model.JobStatusEnum_NotStarted,
+		Data:     nil,
+		Parent:   &jobId,
+		Priority: dto.JobPriority_Medium,
+		DedupKey: &expectedDedupKey,
+	}
+
+	assert.Equal(t, expected, *actual)
+	assert.Equal(t, expectedData, actualData)
+}
+
+func Test_GenerateChaptersDedupKey_Overwrite_ShouldNotMatchDefaultRun(t *testing.T) {
+	id, _ := uuid.NewRandom()
+
+	defaultKey := generateChaptersJob.DedupKey(dto.GenerateChaptersData{MediaId: id, Interval: defaultChapterInterval})
+	overwriteKey := generateChaptersJob.DedupKey(dto.GenerateChaptersData{MediaId: id, Interval: defaultChapterInterval, Overwrite: true})
+	intervalKey := generateChaptersJob.DedupKey(dto.GenerateChaptersData{MediaId: id, Interval: 60})
+
+	assert.NotEqual(t, *defaultKey, *overwriteKey)
+	assert.NotEqual(t, *defaultKey, *intervalKey)
+}
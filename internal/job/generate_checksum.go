@@ -1,41 +1,79 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/go-jet/jet/v2/postgres"
 
 	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
 	"github.com/slugger7/exorcist/internal/dto"
 	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/repository"
 )
 
-type GenerateChecksumData struct {
-	MediaId uuid.UUID `json:"mediaId"`
-}
+var generateChecksumJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.GenerateChecksumData]{
+	Type:        model.JobTypeEnum_GenerateChecksum,
+	Priority:    dto.JobPriority_Low,
+	MaxAttempts: 3,
+	Idempotent:  true,
+	Validate: func(repo repository.Repository, d dto.GenerateChecksumData) error {
+		if d.Algorithm != nil && !slices.Contains(model.ChecksumAlgorithmEnumAllValues, *d.Algorithm) {
+			return fmt.Errorf("unsupported checksum algorithm: %v", *d.Algorithm)
+		}
 
+		_, err := validateMedia(repo, d.MediaId)
+		return err
+	},
+	// partial and full checksums of the same media are calculated by separate jobs
+	DedupKey: func(d dto.GenerateChecksumData) *string {
+		if d.Full {
+			return jobRegistry.Key(model.JobTypeEnum_GenerateChecksum, d.MediaId, "full")
+		}
+		return jobRegistry.Key(model.JobTypeEnum_GenerateChecksum, d.MediaId)
+	},
+	Run: (*JobRunner).GenerateChecksum,
+})
+
+// CreateGenerateChecksumJob creates a job that calculates the partial checksum of the media
 func CreateGenerateChecksumJob(mediaId, jobId uuid.UUID) (*model.Job, error) {
-	d := GenerateChecksumData{
-		MediaId: mediaId,
-	}
-	js, err := json.Marshal(d)
+	job, err := generateChecksumJob.NewJob(dto.GenerateChecksumData{MediaId: mediaId}, &jobId)
 	if err != nil {
-		return nil, errs.BuildError(err, "could not marshal generate checksum data for: %v", mediaId)
+		return nil, errs.BuildError(err, "could not create generate checksum job for: %v", mediaId)
 	}
-	data := string(js)
-	job := model.Job{
-		JobType:  model.JobTypeEnum_GenerateChecksum,
-		Status:   model.JobStatusEnum_NotStarted,
-		Data:     &data,
-		Parent:   &jobId,
-		Priority: dto.JobPriority_Low,
+
+	return job, nil
+}
+
+// CreateGenerateFullChecksumJob creates a job that hashes the whole file of the media.
+// It runs after the other work in the queue as it has to read the whole file
+func CreateGenerateFullChecksumJob(mediaId, jobId uuid.UUID, algorithm model.ChecksumAlgorithmEnum) (*model.Job, error) {
+	job, err := generateChecksumJob.NewJob(dto.GenerateChecksumData{MediaId: mediaId, Full: true, Algorithm: &algorithm}, &jobId)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not create generate full checksum job for: %v", mediaId)
 	}
 
-	return &job, nil
+	job.Priority = dto.JobPriority_Lowest
+
+	return job, nil
+}
+
+// checksumAlgorithm is the configured algorithm or md5 when none was configured
+func (jr *JobRunner) checksumAlgorithm() model.ChecksumAlgorithmEnum {
+	if jr.env == nil || jr.env.ChecksumAlgorithm == "" {
+		return model.ChecksumAlgorithmEnum_Md5
+	}
+	return jr.env.ChecksumAlgorithm
 }
 
-func (jr *JobRunner) GenerateChecksum(job *model.Job) error {
-	var jobData GenerateChecksumData
+func (jr *JobRunner) GenerateChecksum(ctx context.Context, job *model.Job) error {
+	var jobData dto.GenerateChecksumData
 	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
 		return errs.BuildError(err, "error parsing job data: %v", job.Data)
 	}
@@ -45,18 +83,67 @@ func (jr *JobRunner) GenerateChecksum(job *model.Job) error {
 		return errs.BuildError(err, "error fetching video with library path by id: %v", jobData.MediaId)
 	}
 
-	jr.logger.Infof("Calculating checksum for %v", jobMedia.Path)
+	algorithm := jr.checksumAlgorithm()
+	if jobData.Algorithm != nil {
+		algorithm = *jobData.Algorithm
+	}
+
+	if jobData.Full {
+		jr.log(ctx).Infof("Calculating %v checksum for %v", algorithm, jobMedia.Path)
+
+		checksum, err := media.CalculateChecksum(jobMedia.Path, algorithm)
+		if err != nil {
+			return errs.BuildError(err, "error calculating %v checksum for %v", algorithm, jobMedia.Path)
+		}
 
-	checksum, err := media.CalculateMD5(jobMedia.Path)
+		jobMedia.Checksum = &checksum.Value
+		jobMedia.ChecksumAlgorithm = &checksum.Algorithm
+
+		if err := jr.repo.Media().UpdateChecksum(*jobMedia); err != nil {
+			return errs.BuildError(err, "error updating video checksum")
+		}
+
+		return nil
+	}
+
+	jr.log(ctx).Infof("Calculating partial %v checksum for %v", algorithm, jobMedia.Path)
+
+	partial, err := media.CalculatePartialChecksum(jobMedia.Path, algorithm)
 	if err != nil {
-		return errs.BuildError(err, "error calculating md5sum for %v", jobMedia.Path)
+		return errs.BuildError(err, "error calculating partial %v checksum for %v", algorithm, jobMedia.Path)
 	}
 
-	jobMedia.Checksum = &checksum
+	jobMedia.PartialChecksum = &partial.Value
+	jobMedia.PartialChecksumAlgorithm = &partial.Algorithm
 
-	if err := jr.repo.Media().UpdateChecksum(*jobMedia); err != nil {
-		return errs.BuildError(err, "error updating video checksum")
+	if _, err := jr.repo.Media().Update(jobMedia.Media, postgres.ColumnList{table.Media.PartialChecksum, table.Media.PartialChecksumAlgorithm}); err != nil {
+		return errs.BuildError(err, "error updating partial checksum")
 	}
 
 	return nil
 }
+
+// checksumJobs are the jobs that calculate the checksums of new or changed media which were not calculated yet.
+// The full checksum is only calculated when it is enabled
+func (jr *JobRunner) checksumJobs(mediaId, jobId uuid.UUID, calculated fileChecksums) ([]model.Job, error) {
+	jobs := []model.Job{}
+	if calculated.partial == nil {
+		partialJob, err := CreateGenerateChecksumJob(mediaId, jobId)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *partialJob)
+	}
+
+	algorithm := jr.checksumAlgorithm()
+	if jr.env == nil || !jr.env.FullChecksum || (calculated.full != nil && calculated.full.Algorithm == algorithm) {
+		return jobs, nil
+	}
+
+	fullJob, err := CreateGenerateFullChecksumJob(mediaId, jobId, algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(jobs, *fullJob), nil
+}
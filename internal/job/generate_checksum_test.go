@@ -7,6 +7,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/media"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,14 +23,53 @@ func Test_CreateGenerateChecksumJob(t *testing.T) {
 	actual.Data = nil
 
 	expectedData := fmt.Sprintf(`{"mediaId":"%v"}`, id)
+	expectedDedupKey := fmt.Sprintf("generate_checksum:%v", id)
 	expected := model.Job{
 		JobType:  model.JobTypeEnum_GenerateChecksum,
 		Status:   model.JobStatusEnum_NotStarted,
 		Data:     nil,
 		Priority: dto.JobPriority_Low,
 		Parent:   &jobId,
+		DedupKey: &expectedDedupKey,
 	}
 
 	assert.Equal(t, expected, *actual)
 	assert.Equal(t, expectedData, actualData)
 }
+
+func Test_GenerateChecksumDedupKey_Full_ShouldNotMatchPartial(t *testing.T) {
+	id, _ := uuid.NewRandom()
+
+	partialKey := generateChecksumJob.DedupKey(dto.GenerateChecksumData{MediaId: id})
+	fullKey := generateChecksumJob.DedupKey(dto.GenerateChecksumData{MediaId: id, Full: true})
+
+	assert.NotEqual(t, *partialKey, *fullKey)
+}
+
+func Test_ChecksumJobs_WithCalculatedPartialChecksum_ShouldOnlyCreateFullChecksumJob(t *testing.T) {
+	jr := &JobRunner{env: &environment.EnvironmentVariables{
+		ChecksumAlgorithm: model.ChecksumAlgorithmEnum_Sha256,
+		FullChecksum:      true,
+	}}
+	id, jobId := uuid.New(), uuid.New()
+
+	jobs, err := jr.checksumJobs(id, jobId, fileChecksums{
+		partial: &media.Checksum{Value: "abc", Algorithm: model.ChecksumAlgorithmEnum_Sha256},
+	})
+	assert.Nil(t, err)
+
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, dto.JobPriority_Lowest, jobs[0].Priority)
+	assert.Equal(t, fmt.Sprintf(`{"mediaId":"%v","full":true,"algorithm":"sha256"}`, id), *jobs[0].Data)
+}
+
+func Test_ChecksumJobs_WithoutFullChecksum_ShouldOnlyCreatePartialChecksumJob(t *testing.T) {
+	jr := &JobRunner{env: &environment.EnvironmentVariables{FullChecksum: false}}
+	id := uuid.New()
+
+	jobs, err := jr.checksumJobs(id, uuid.New(), fileChecksums{})
+	assert.Nil(t, err)
+
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, fmt.Sprintf(`{"mediaId":"%v"}`, id), *jobs[0].Data)
+}
@@ -0,0 +1,134 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/ffmpeg"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+// fingerprintFrames is the number of frames that are sampled evenly across a video for its fingerprint.
+// Changing it makes existing fingerprints incomparable to new ones
+const fingerprintFrames = 10
+
+var generateFingerprintJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.GenerateFingerprintData]{
+	Type:        model.JobTypeEnum_GenerateFingerprint,
+	Priority:    dto.JobPriority_Low,
+	MaxAttempts: 2,
+	Idempotent:  true,
+	Validate: func(repo repository.Repository, d dto.GenerateFingerprintData) error {
+		media, err := validateMedia(repo, d.MediaId)
+		if err != nil {
+			return err
+		}
+
+		if media.Video == nil {
+			return fmt.Errorf("media is not of type video: %v", d.MediaId.String())
+		}
+
+		return nil
+	},
+	// a job that overwrites the fingerprint is not the same work as one that keeps an existing fingerprint
+	DedupKey: func(d dto.GenerateFingerprintData) *string {
+		if d.Overwrite {
+			return jobRegistry.Key(model.JobTypeEnum_GenerateFingerprint, d.MediaId, "overwrite")
+		}
+		return jobRegistry.Key(model.JobTypeEnum_GenerateFingerprint, d.MediaId)
+	},
+	Run: (*JobRunner).generateFingerprint,
+})
+
+func CreateGenerateFingerprintJob(mediaId uuid.UUID, jobId *uuid.UUID, overwrite bool, priority int16) (*model.Job, error) {
+	job, err := generateFingerprintJob.NewJob(dto.GenerateFingerprintData{MediaId: mediaId, Overwrite: overwrite}, jobId)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not create generate fingerprint job for: %v", mediaId)
+	}
+
+	job.Priority = priority
+
+	return job, nil
+}
+
+func (jr *JobRunner) generateFingerprint(ctx context.Context, job *model.Job) error {
+	var jobData dto.GenerateFingerprintData
+	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
+		return errs.BuildError(err, "error parsing job data for generate fingerprint: %v", job.Data)
+	}
+
+	m, err := jr.repo.Media().GetById(jobData.MediaId)
+	if err != nil {
+		return errs.BuildError(err, "could not find media by id for generate fingerprint job %v", jobData.MediaId.String())
+	}
+
+	if m == nil || m.Video == nil {
+		return fmt.Errorf("media was not of type video: %v", jobData.MediaId.String())
+	}
+
+	if m.Video.Fingerprint != nil && !jobData.Overwrite {
+		jr.log(ctx).Infof("fingerprint already exists for %v and overwrite was set to false", jobData.MediaId)
+		return nil
+	}
+
+	if m.Video.Runtime <= 0 {
+		return fmt.Errorf("video has no runtime to sample frames from: %v", m.Media.Path)
+	}
+
+	jr.log(ctx).Infof("Generating fingerprint for %v", m.Media.Path)
+
+	fingerprint, err := sampleFingerprint(ctx, m.Media.Path, m.Video.Runtime)
+	if err != nil {
+		return err
+	}
+
+	bytes := fingerprint.Bytes()
+	video := model.Video{MediaID: m.Media.ID, Fingerprint: &bytes}
+	if err := jr.repo.Video().UpdateByMediaId(video, postgres.ColumnList{table.Video.Fingerprint}); err != nil {
+		return errs.BuildError(err, "could not update fingerprint for %v", m.Media.ID)
+	}
+
+	return nil
+}
+
+// sampleFingerprint extracts frames at the same relative positions of every video so that their fingerprints can
+// be compared regardless of resolution or encoding
+func sampleFingerprint(ctx context.Context, path string, runtime float64) (media.Fingerprint, error) {
+	dir, err := os.MkdirTemp("", "exorcist-fingerprint-*")
+	if err != nil {
+		return nil, errs.BuildError(err, "could not create directory for fingerprint frames")
+	}
+	defer os.RemoveAll(dir)
+
+	fingerprint := make(media.Fingerprint, 0, fingerprintFrames)
+	for i := range fingerprintFrames {
+		if err := ctx.Err(); err != nil {
+			return nil, errs.BuildError(err, "stopped while sampling frames")
+		}
+
+		timestamp := runtime * (float64(i) + 0.5) / fingerprintFrames
+		frame := filepath.Join(dir, fmt.Sprintf("%v.png", i))
+		if err := ffmpeg.ImageAt(ctx, path, timestamp, frame, media.PerceptualHashSize, media.PerceptualHashSize); err != nil {
+			return nil, errs.BuildError(err, "could not extract frame at %v for fingerprint", timestamp)
+		}
+
+		hash, err := media.PerceptualHashFile(frame)
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint = append(fingerprint, hash)
+	}
+
+	return fingerprint, nil
+}
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateGenerateFingerprintJob(t *testing.T) {
+	jobId, _ := uuid.NewRandom()
+	id, _ := uuid.NewRandom()
+
+	actual, err := CreateGenerateFingerprintJob(id, &jobId, true, dto.JobPriority_High)
+	assert.Nil(t, err)
+
+	actualData := *actual.Data
+	actual.Data = nil
+
+	expectedData := fmt.Sprintf(`{"mediaId":"%v","overwrite":true}`, id)
+	expectedDedupKey := fmt.Sprintf("generate_fingerprint:%v:overwrite", id)
+	expected := model.Job{
+		JobType:  model.JobTypeEnum_GenerateFingerprint,
+		Status:   model.JobStatusEnum_NotStarted,
+		Data:     nil,
+		Priority: dto.JobPriority_High,
+		Parent:   &jobId,
+		DedupKey: &expectedDedupKey,
+	}
+
+	assert.Equal(t, expected, *actual)
+	assert.Equal(t, expectedData, actualData)
+}
+
+func Test_GenerateFingerprintDedupKey_Overwrite_ShouldNotMatchDefaultRun(t *testing.T) {
+	id, _ := uuid.NewRandom()
+
+	defaultKey := generateFingerprintJob.DedupKey(dto.GenerateFingerprintData{MediaId: id})
+	overwriteKey := generateFingerprintJob.DedupKey(dto.GenerateFingerprintData{MediaId: id, Overwrite: true})
+
+	assert.NotEqual(t, *defaultKey, *overwriteKey)
+}
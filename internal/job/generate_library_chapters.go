@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+var generateLibraryChaptersJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.GenerateLibraryChaptersData]{
+	Type:     model.JobTypeEnum_GenerateLibraryChapters,
+	Priority: dto.JobPriority_Medium,
+	Defaults: func(d *dto.GenerateLibraryChaptersData) {
+		if d.Interval == 0 {
+			d.Interval = defaultChapterInterval
+		}
+	},
+	Validate: func(repo repository.Repository, d dto.GenerateLibraryChaptersData) error {
+		_, err := validateLibrary(repo, d.LibraryId)
+		return err
+	},
+	DedupKey: func(d dto.GenerateLibraryChaptersData) *string {
+		return jobRegistry.Key(model.JobTypeEnum_GenerateLibraryChapters, d.LibraryId)
+	},
+	Run: (*JobRunner).generateLibraryChapters,
+})
+
+func (jr *JobRunner) generateLibraryChapters(ctx context.Context, job *model.Job) error {
+	var jobData dto.GenerateLibraryChaptersData
+	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
+		return errs.BuildError(err, "error parsing job data for generate library chapters: %v", job.Data)
+	}
+
+	return jr.createLibraryVideoJobs(ctx, job, jobData.LibraryId, jobData.BatchSize, func(mediaId uuid.UUID) (*model.Job, error) {
+		return CreateGenerateChaptersJob(mediaId, &job.ID, jobData.Interval, jobData.MaxDimension, jobData.Overwrite, job.Priority)
+	})
+}
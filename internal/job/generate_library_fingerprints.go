@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+var generateLibraryFingerprintsJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.GenerateLibraryFingerprintsData]{
+	Type:     model.JobTypeEnum_GenerateLibraryFingerprints,
+	Priority: dto.JobPriority_Low,
+	Validate: func(repo repository.Repository, d dto.GenerateLibraryFingerprintsData) error {
+		_, err := validateLibrary(repo, d.LibraryId)
+		return err
+	},
+	DedupKey: func(d dto.GenerateLibraryFingerprintsData) *string {
+		return jobRegistry.Key(model.JobTypeEnum_GenerateLibraryFingerprints, d.LibraryId)
+	},
+	Run: (*JobRunner).generateLibraryFingerprints,
+})
+
+func (jr *JobRunner) generateLibraryFingerprints(ctx context.Context, job *model.Job) error {
+	var jobData dto.GenerateLibraryFingerprintsData
+	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
+		return errs.BuildError(err, "error parsing job data for generate library fingerprints: %v", job.Data)
+	}
+
+	return jr.createLibraryVideoJobs(ctx, job, jobData.LibraryId, jobData.BatchSize, func(mediaId uuid.UUID) (*model.Job, error) {
+		return CreateGenerateFingerprintJob(mediaId, &job.ID, jobData.Overwrite, job.Priority)
+	})
+}
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -11,9 +12,35 @@ import (
 	"github.com/slugger7/exorcist/internal/dto"
 	errs "github.com/slugger7/exorcist/internal/errors"
 	"github.com/slugger7/exorcist/internal/ffmpeg"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/repository"
 )
 
+var generateThumbnailJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.GenerateThumbnailData]{
+	Type:        model.JobTypeEnum_GenerateThumbnail,
+	Priority:    dto.JobPriority_MediumHigh,
+	MaxAttempts: 3,
+	Idempotent:  true,
+	Defaults: func(d *dto.GenerateThumbnailData) {
+		if d.RelationType == nil {
+			v := model.MediaRelationTypeEnum_Thumbnail
+			d.RelationType = &v
+		}
+	},
+	Validate: func(repo repository.Repository, d dto.GenerateThumbnailData) error {
+		if _, err := repo.Video().GetByIdWithMedia(d.MediaId); err != nil {
+			return errs.BuildError(err, "could not find video for generate thumbnail job: %v", d.MediaId)
+		}
+
+		return nil
+	},
+	DedupKey: func(d dto.GenerateThumbnailData) *string {
+		return jobRegistry.Key(model.JobTypeEnum_GenerateThumbnail, d.MediaId, d.Path)
+	},
+	Run: (*JobRunner).GenerateThumbnail,
+})
+
 func CreateGenerateThumbnailJob(
 	video model.Video,
 	jobId *uuid.UUID,
@@ -33,22 +60,9 @@ func CreateGenerateThumbnailJob(
 		Metadata:     metadata,
 	}
 
-	if d.RelationType == nil {
-		v := model.MediaRelationTypeEnum_Thumbnail
-		d.RelationType = &v
-	}
-
-	js, err := json.Marshal(d)
+	job, err := generateThumbnailJob.NewJob(d, jobId)
 	if err != nil {
-		return nil, errs.BuildError(err, "could not marshal generate thumbnail data")
-	}
-	data := string(js)
-	job := &model.Job{
-		JobType:  model.JobTypeEnum_GenerateThumbnail,
-		Status:   model.JobStatusEnum_NotStarted,
-		Data:     &data,
-		Parent:   jobId,
-		Priority: dto.JobPriority_MediumHigh,
+		return nil, errs.BuildError(err, "could not create generate thumbnail job for: %v", video.ID)
 	}
 
 	return job, nil
@@ -59,7 +73,7 @@ func createAssetDirectory(path string) error {
 	return os.MkdirAll(dir, os.ModePerm)
 }
 
-func (jr *JobRunner) GenerateThumbnail(job *model.Job) error {
+func (jr *JobRunner) GenerateThumbnail(ctx context.Context, job *model.Job) error {
 	var jobData dto.GenerateThumbnailData
 	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
 		return errs.BuildError(err, "error parsing job data: %v", job.Data)
@@ -89,7 +103,7 @@ func (jr *JobRunner) GenerateThumbnail(job *model.Job) error {
 		return errs.BuildError(err, "could not create path for asset")
 	}
 
-	if err := ffmpeg.ImageAt(video.Path, jobData.Timestamp, jobData.Path, jobData.Width, jobData.Height); err != nil {
+	if err := ffmpeg.ImageAt(ctx, video.Path, jobData.Timestamp, jobData.Path, jobData.Width, jobData.Height); err != nil {
 		return errs.BuildError(err, "could not create image at timestamp: %v, video: %v", jobData.Timestamp, video.Runtime)
 	}
 
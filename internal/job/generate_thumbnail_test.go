@@ -24,12 +24,14 @@ func Test_CreateGenerateThumbnailJob(t *testing.T) {
 	actual.Data = nil
 
 	expectedData := fmt.Sprintf(`{"mediaId":"%v","path":"%v","timestamp":%v,"height":%v,"width":%v,"relationType":"thumbnail","metadata":null}`, id, imagePath, timestamp, height, width)
+	expectedDedupKey := fmt.Sprintf("generate_thumbnail:%v:%v", id, imagePath)
 	expected := model.Job{
 		JobType:  model.JobTypeEnum_GenerateThumbnail,
 		Status:   model.JobStatusEnum_NotStarted,
 		Data:     nil,
 		Parent:   &jobId,
 		Priority: dto.JobPriority_MediumHigh,
+		DedupKey: &expectedDedupKey,
 	}
 
 	assert.Equal(t, expected, *actual, "Expected job should be equal to actual job")
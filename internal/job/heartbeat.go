@@ -0,0 +1,99 @@
+package job
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultHeartbeatInterval = 15 * time.Second
+
+func (jr *JobRunner) heartbeatInterval() time.Duration {
+	if jr.env.JobHeartbeatInterval < 1 {
+		return defaultHeartbeatInterval
+	}
+	return time.Duration(jr.env.JobHeartbeatInterval) * time.Second
+}
+
+// heartbeat keeps the jobs of this worker alive and reclaims the jobs of workers whose heartbeat expired
+func (jr *JobRunner) heartbeat() {
+	defer jr.wg.Done()
+
+	ticker := time.NewTicker(jr.heartbeatInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-jr.shutdownCtx.Done():
+			jr.logger.Debug("Shutdown signal received. Stopping heartbeat")
+			return
+		case <-ticker.C:
+			jr.beat()
+			jr.reclaimExpired()
+		}
+	}
+}
+
+func (jr *JobRunner) beat() {
+	ids := jr.runningJobIds()
+	if len(ids) == 0 {
+		return
+	}
+
+	alive, err := jr.repo.Job().Heartbeat(ids, jr.env.WorkerId)
+	if err != nil {
+		jr.logger.Errorf("could not send heartbeat for running jobs: %v", err.Error())
+		return
+	}
+
+	lost := []uuid.UUID{}
+	for _, id := range ids {
+		if !slices.Contains(alive, id) {
+			lost = append(lost, id)
+		}
+	}
+
+	jr.abandonJobs(lost)
+}
+
+// reclaimExpired puts the jobs of workers that stopped sending heartbeats back in the queue
+func (jr *JobRunner) reclaimExpired() {
+	resumed, cancelled := recoverInterrupted(jr.repo, jr.logger, "", time.Now().Add(-heartbeatTimeout(jr.env)))
+
+	for _, j := range cancelled {
+		jr.ws.JobUpdate(j)
+	}
+
+	for _, j := range resumed {
+		jr.ws.JobUpdate(j)
+	}
+
+	if len(resumed) > 0 {
+		jr.dispatchWorkers()
+	}
+}
+
+// abandonJobs stops jobs that are no longer owned by this worker without touching their status
+func (jr *JobRunner) abandonJobs(ids []uuid.UUID) {
+	jr.cancelMu.Lock()
+	defer jr.cancelMu.Unlock()
+
+	for _, id := range ids {
+		if cancel, ok := jr.jobCancels[id]; ok {
+			jr.logger.Warningf("Job %v is no longer owned by worker %v. Stopping it", id.String(), jr.env.WorkerId)
+			jr.abandoned[id] = true
+			cancel(nil)
+		}
+	}
+}
+
+func (jr *JobRunner) wasAbandoned(id uuid.UUID) bool {
+	jr.cancelMu.Lock()
+	defer jr.cancelMu.Unlock()
+
+	abandoned := jr.abandoned[id]
+	delete(jr.abandoned, id)
+
+	return abandoned
+}
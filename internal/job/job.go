@@ -3,16 +3,22 @@ package job
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/environment"
 	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/logger"
 	"github.com/slugger7/exorcist/internal/models"
 	"github.com/slugger7/exorcist/internal/repository"
+	jobRepository "github.com/slugger7/exorcist/internal/repository/job"
 	"github.com/slugger7/exorcist/internal/service"
 	"github.com/slugger7/exorcist/internal/websockets"
 )
@@ -26,8 +32,29 @@ type JobRunner struct {
 	shutdownCtx context.Context
 	wg          *sync.WaitGroup
 	ws          websockets.Websockets
+	workerMu    sync.Mutex
+	workers     int
+	running     map[model.JobTypeEnum]int
+	cancelMu    sync.Mutex
+	jobCancels  map[uuid.UUID]context.CancelCauseFunc
+	abandoned   map[uuid.UUID]bool
 }
 
+const cancelPollInterval = time.Second
+
+// errCancelledByUser is the cause of the context of a running job that was cancelled
+var errCancelledByUser = errors.New("cancelled by user")
+
+const defaultPollInterval = 30 * time.Second
+
+// jobCreatedChannel is notified by a trigger whenever jobs are inserted
+const jobCreatedChannel = "job_created"
+
+const maxRetryDelay = time.Hour
+
+// Registry holds the job types that can be created and run. Every job type registers itself in the file that runs it
+var Registry = jobRegistry.New[*JobRunner]()
+
 var jobRunnerInstance *JobRunner
 
 func New(
@@ -44,32 +71,74 @@ func New(
 		repo := repository.New(env, context.Background())
 		jobRunnerInstance = &JobRunner{
 			env:         env,
-			service:     service.New(repo, env, ch, shutdownCtx),
+			service:     service.New(repo, env, shutdownCtx, Registry),
 			repo:        repo,
 			logger:      logger,
 			ch:          ch,
 			wg:          wg,
 			shutdownCtx: shutdownCtx,
 			ws:          ws,
+			running:     map[model.JobTypeEnum]int{},
+			jobCancels:  map[uuid.UUID]context.CancelCauseFunc{},
+			abandoned:   map[uuid.UUID]bool{},
 		}
 
-		logger.Debug("Job runner instance created")
-		wg.Add(1)
+		logger.Debugf("Job runner instance created for worker %v", env.WorkerId)
+		wg.Add(4)
 		go jobRunnerInstance.loop()
+		go jobRunnerInstance.cancelWatcher()
+		go jobRunnerInstance.scheduler()
+		go jobRunnerInstance.heartbeat()
 	}
 
 	return ch
 }
 
+func (jr *JobRunner) pollInterval() time.Duration {
+	if jr.env.JobPollInterval < 1 {
+		return defaultPollInterval
+	}
+	return time.Duration(jr.env.JobPollInterval) * time.Second
+}
+
+// listen subscribes to the notifications sent when jobs are inserted by any process.
+// A nil channel is returned when listening fails so that the runner falls back to polling
+func (jr *JobRunner) listen() (<-chan *pq.Notification, func()) {
+	listener, err := jr.repo.Listen(jobCreatedChannel)
+	if err != nil {
+		jr.logger.Errorf("could not listen for created jobs. Falling back to polling: %v", err.Error())
+		return nil, func() {}
+	}
+
+	jr.logger.Infof("Listening for created jobs on %v", jobCreatedChannel)
+	return listener.NotificationChannel(), func() {
+		if err := listener.Close(); err != nil {
+			jr.logger.Errorf("could not close job listener: %v", err.Error())
+		}
+	}
+}
+
 func (jr *JobRunner) loop() {
 	defer jr.wg.Done()
 
+	notifications, closeListener := jr.listen()
+	defer closeListener()
+
+	// notifications can be missed while the listener reconnects so the queue is also checked periodically
+	poll := time.NewTicker(jr.pollInterval())
+	defer poll.Stop()
+
 	jr.logger.Infof("Running jobs")
 	for {
 		select {
 		case <-jr.shutdownCtx.Done():
 			jr.logger.Debug("Shutdown signal received. Shutting down")
 			return
+		case <-notifications:
+			jr.logger.Debug("Job runner notified of created jobs")
+			jr.dispatchWorkers()
+		case <-poll.C:
+			jr.dispatchWorkers()
 		case _, ok := <-jr.ch:
 			jr.logger.Debug("Job runner reading from channel")
 			if !ok {
@@ -77,13 +146,81 @@ func (jr *JobRunner) loop() {
 				return
 			}
 
-			jr.logger.Info("Processing jobs")
-			if err := jr.processJobs(); err != nil {
-				jr.logger.Errorf("Error received while processing jobs. Stopping job runner", err.Error())
-				return
-			}
+			jr.dispatchWorkers()
+		}
+	}
+}
+
+func (jr *JobRunner) maxWorkers() int {
+	if jr.env.JobWorkers < 1 {
+		return 1
+	}
+	return jr.env.JobWorkers
+}
+
+// dispatchWorkers starts workers until the total worker count is reached.
+// Workers that are already running will keep picking up jobs until there are none left for them.
+func (jr *JobRunner) dispatchWorkers() {
+	jr.workerMu.Lock()
+	defer jr.workerMu.Unlock()
+
+	for jr.workers < jr.maxWorkers() {
+		jr.workers++
+		jr.wg.Add(1)
+		go jr.worker(jr.workers)
+	}
+}
+
+func (jr *JobRunner) worker(id int) {
+	defer jr.wg.Done()
+	defer func() {
+		jr.workerMu.Lock()
+		jr.workers--
+		jr.workerMu.Unlock()
+	}()
+
+	jr.logger.Infof("Worker %v processing jobs", id)
+	if err := jr.processJobs(); err != nil {
+		jr.logger.Errorf("Error received while processing jobs. Stopping worker %v: %v", id, err.Error())
+	}
+}
+
+// saturatedJobTypes returns the job types that have reached their concurrency limit
+func saturatedJobTypes(running map[model.JobTypeEnum]int, limits map[model.JobTypeEnum]int) []model.JobTypeEnum {
+	saturated := []model.JobTypeEnum{}
+	for _, t := range model.JobTypeEnumAllValues {
+		limit, ok := limits[t]
+		if ok && running[t] >= limit {
+			saturated = append(saturated, t)
 		}
 	}
+
+	return saturated
+}
+
+// claimJob fetches the next job that does not exceed its job type's concurrency limit and reserves a slot for it.
+// Claiming is serialised so that workers never pick up the same job.
+func (jr *JobRunner) claimJob() (*model.Job, error) {
+	jr.workerMu.Lock()
+	defer jr.workerMu.Unlock()
+
+	job, err := jr.repo.Job().GetNextJob(saturatedJobTypes(jr.running, jr.env.JobConcurrency), jr.env.WorkerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if job != nil {
+		jr.running[job.JobType]++
+	}
+
+	return job, nil
+}
+
+func (jr *JobRunner) releaseJob(job *model.Job) {
+	jr.workerMu.Lock()
+	defer jr.workerMu.Unlock()
+
+	jr.running[job.JobType]--
 }
 
 func (jr *JobRunner) disableJobChecker(job *model.Job) error {
@@ -100,7 +237,7 @@ func (jr *JobRunner) processJobs() error {
 		case <-jr.shutdownCtx.Done():
 			return fmt.Errorf("shutdown signal received. Stopping job loop")
 		default:
-			job, err := jr.repo.Job().GetNextJob()
+			job, err := jr.claimJob()
 			if err != nil {
 				return errs.BuildError(err, "Failed to fetch next job")
 			}
@@ -109,91 +246,248 @@ func (jr *JobRunner) processJobs() error {
 				return nil
 			}
 
-			if err := jr.disableJobChecker(job); err != nil {
-				job.Status = model.JobStatusEnum_Cancelled
-				errorMessage := jr.marshallJobError(err.Error())
-				job.Outcome = &errorMessage
-				if err := jr.repo.Job().UpdateJobStatus(job); err != nil {
-					return errs.BuildError(err, "Could not update not implemented job %v. Killing to prevent infinite loop", job.JobType)
-				}
-				jr.ws.JobUpdate(*job)
-				continue
+			err = jr.processJob(job)
+			jr.releaseJob(job)
+			if err != nil {
+				return err
 			}
 
-			job.Status = model.JobStatusEnum_InProgress
-			if err := jr.repo.Job().UpdateJobStatus(job); err != nil {
-				return errs.BuildError(err, "Failed to update job status")
-				// this should probably stop the job runner
-			}
+			// jobs can create child jobs so idle workers are started to pick them up
+			jr.dispatchWorkers()
+		}
+	}
+}
 
-			jr.ws.JobUpdate(*job)
+func (jr *JobRunner) maxAttempts(jobType model.JobTypeEnum) int {
+	if attempts, ok := jr.env.JobMaxAttempts[jobType]; ok {
+		return attempts
+	}
 
-			jobFunc, err := jr.jobFuncResolver(job.JobType)
-			if err != nil {
-				job.Status = model.JobStatusEnum_Cancelled
-				errorMessage := jr.marshallJobError(err.Error())
-				job.Outcome = &errorMessage
-				if err := jr.repo.Job().UpdateJobStatus(job); err != nil {
-					return errs.BuildError(err, "Could not update not implemented job %v. Killing to prevent infinite loop", job.JobType)
-				}
-				jr.ws.JobUpdate(*job)
+	if h, ok := Registry.Get(jobType); ok && h.MaxAttempts > 0 {
+		return h.MaxAttempts
+	}
+
+	return 1
+}
+
+// retryDelay doubles the base delay (in seconds) for every attempt that has already been made
+func retryDelay(baseDelay int, attempts int16) time.Duration {
+	delay := time.Duration(baseDelay) * time.Second
+	for i := int16(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+
+	return delay
+}
+
+// wakeAfter starts workers once the delay has passed so that jobs scheduled for later are picked up
+func (jr *JobRunner) wakeAfter(delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		if jr.shutdownCtx.Err() != nil {
+			return
+		}
+		jr.dispatchWorkers()
+	})
+}
+
+// trackJob creates the context that a job runs in so that it can be cancelled while it is running
+func (jr *JobRunner) trackJob(id uuid.UUID) context.Context {
+	jr.cancelMu.Lock()
+	defer jr.cancelMu.Unlock()
+
+	ctx, cancel := context.WithCancelCause(jr.shutdownCtx)
+	jr.jobCancels[id] = cancel
+
+	return ctx
+}
+
+func (jr *JobRunner) untrackJob(id uuid.UUID) {
+	jr.cancelMu.Lock()
+	defer jr.cancelMu.Unlock()
+
+	if cancel, ok := jr.jobCancels[id]; ok {
+		cancel(nil)
+		delete(jr.jobCancels, id)
+	}
+}
+
+func (jr *JobRunner) cancelJobs(ids []uuid.UUID) {
+	jr.cancelMu.Lock()
+	defer jr.cancelMu.Unlock()
+
+	for _, id := range ids {
+		if cancel, ok := jr.jobCancels[id]; ok {
+			jr.logger.Infof("Cancelling running job %v", id.String())
+			cancel(errCancelledByUser)
+		}
+	}
+}
+
+func (jr *JobRunner) runningJobIds() []uuid.UUID {
+	jr.cancelMu.Lock()
+	defer jr.cancelMu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(jr.jobCancels))
+	for id := range jr.jobCancels {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// cancelWatcher polls the statuses of running jobs and cancels the context of any job that has been cancelled
+func (jr *JobRunner) cancelWatcher() {
+	defer jr.wg.Done()
+
+	ticker := time.NewTicker(cancelPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-jr.shutdownCtx.Done():
+			jr.logger.Debug("Shutdown signal received. Stopping cancel watcher")
+			return
+		case <-ticker.C:
+			ids := jr.runningJobIds()
+			if len(ids) == 0 {
 				continue
 			}
 
-			if err := jobFunc(job); err != nil {
-				jr.logger.Errorf("Job finished with errors: %v", err.Error())
-				job.Status = model.JobStatusEnum_Failed
-				errText := jr.marshallJobError(err.Error())
-				job.Outcome = &errText
-				if erro := jr.repo.Job().UpdateJobStatus(job); erro != nil {
-					return errs.BuildError(erro, "Could not update job status after error. Killing to prevent infinite loop")
-				}
-				jr.ws.JobUpdate(*job)
+			cancelled, err := jr.repo.Job().GetCancelledIds(ids)
+			if err != nil {
+				jr.logger.Errorf("could not check for cancelled jobs: %v", err.Error())
 				continue
 			}
 
-			job.Status = model.JobStatusEnum_Completed
-			if err := jr.repo.Job().UpdateJobStatus(job); err != nil {
-				return errs.BuildError(err, "Could not update job status after success. Killing to prevent infinite loop")
-			}
-			jr.ws.JobUpdate(*job)
+			jr.cancelJobs(cancelled)
 		}
 	}
 }
 
-type JobFunc func(*model.Job) error
-
-func (jr *JobRunner) jobFuncResolver(jobType model.JobTypeEnum) (JobFunc, error) {
-	var f JobFunc
-	switch jobType {
-	case model.JobTypeEnum_ScanPath:
-		f = func(j *model.Job) error {
-			return jr.ScanPath(j)
-		}
-	case model.JobTypeEnum_GenerateChecksum:
-		f = func(j *model.Job) error {
-			return jr.GenerateChecksum(j)
+func (jr *JobRunner) processJob(job *model.Job) error {
+	if err := jr.disableJobChecker(job); err != nil {
+		job.Status = model.JobStatusEnum_Cancelled
+		errorMessage := jr.marshallJobError(err.Error())
+		job.Outcome = &errorMessage
+		if err := jr.updateJobStatus(job, jr.logger); err != nil {
+			return errs.BuildError(err, "Could not update not implemented job %v. Killing to prevent infinite loop", job.JobType)
 		}
-	case model.JobTypeEnum_GenerateThumbnail:
-		f = func(j *model.Job) error {
-			return jr.GenerateThumbnail(j)
+		return nil
+	}
+
+	jr.ws.JobUpdate(*job)
+
+	jobFunc, err := jr.jobFuncResolver(job.JobType)
+	if err != nil {
+		job.Status = model.JobStatusEnum_Cancelled
+		errorMessage := jr.marshallJobError(err.Error())
+		job.Outcome = &errorMessage
+		if err := jr.updateJobStatus(job, jr.logger); err != nil {
+			return errs.BuildError(err, "Could not update not implemented job %v. Killing to prevent infinite loop", job.JobType)
 		}
-	case model.JobTypeEnum_RefreshMetadata:
-		f = func(j *model.Job) error {
-			return jr.RefreshMetadata(j)
+		return nil
+	}
+
+	jobLog := jr.newJobLogger(job)
+	defer jobLog.Flush()
+	jobLog.Infof("Started attempt %v of %v job %v on worker %v", job.Attempts, job.JobType, job.ID.String(), jr.env.WorkerId)
+
+	ctx := withJobLogger(jr.trackJob(job.ID), jobLog)
+	err = jobFunc(ctx, job)
+	// recorded before untracking which cancels the context without a cause
+	cancelled := errors.Is(context.Cause(ctx), errCancelledByUser)
+	jr.untrackJob(job.ID)
+
+	if jr.wasAbandoned(job.ID) {
+		jobLog.Warningf("Job %v was reclaimed after its heartbeat expired. Discarding the result", job.ID.String())
+		return nil
+	}
+
+	if cancelled {
+		jobLog.Infof("Job %v was cancelled", job.ID.String())
+		job.Status = model.JobStatusEnum_Cancelled
+		errText := jr.marshallJobError(errCancelledByUser.Error())
+		job.Outcome = &errText
+		if erro := jr.updateJobStatus(job, jobLog); erro != nil {
+			return errs.BuildError(erro, "Could not update job status after cancellation. Killing to prevent infinite loop")
 		}
-	case model.JobTypeEnum_RefreshLibraryMetadata:
-		f = func(j *model.Job) error {
-			return jr.refreshLibraryMetadata(j)
+		return nil
+	}
+
+	if err != nil {
+		jobLog.Errorf("Job finished with errors: %v", err.Error())
+		errText := jr.marshallJobError(err.Error())
+		job.Outcome = &errText
+
+		if maxAttempts := jr.maxAttempts(job.JobType); int(job.Attempts) < maxAttempts {
+			delay := retryDelay(jr.env.JobRetryDelay, job.Attempts)
+			jobLog.Infof("Retrying job %v in %v (attempt %v of %v)", job.ID.String(), delay, job.Attempts, maxAttempts)
+			erro := jr.repo.Job().Retry(job, delay)
+			if errors.Is(erro, jobRepository.ErrJobNotInProgress) {
+				jr.keepCancelled(job, jobLog)
+				return nil
+			}
+			if erro != nil {
+				return errs.BuildError(erro, "Could not schedule job retry after error. Killing to prevent infinite loop")
+			}
+			jr.ws.JobUpdate(*job)
+			jr.wakeAfter(delay)
+			return nil
 		}
-	case model.JobTypeEnum_GenerateChapters:
-		f = func(j *model.Job) error {
-			return jr.generateChapters(j)
+
+		job.Status = model.JobStatusEnum_Failed
+		if erro := jr.updateJobStatus(job, jobLog); erro != nil {
+			return errs.BuildError(erro, "Could not update job status after error. Killing to prevent infinite loop")
 		}
-	default:
+		return nil
+	}
+
+	job.Status = model.JobStatusEnum_Completed
+	if err := jr.updateJobStatus(job, jobLog); err != nil {
+		return errs.BuildError(err, "Could not update job status after success. Killing to prevent infinite loop")
+	}
+
+	return nil
+}
+
+// updateJobStatus stores the status of a job that finished. A job that was cancelled while it was finishing stays cancelled
+func (jr *JobRunner) updateJobStatus(job *model.Job, log logger.Logger) error {
+	err := jr.repo.Job().UpdateJobStatus(job)
+	if errors.Is(err, jobRepository.ErrJobNotInProgress) {
+		jr.keepCancelled(job, log)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	jr.ws.JobUpdate(*job)
+	return nil
+}
+
+// keepCancelled reverts the job to the cancelled status that it was given while it was finishing
+func (jr *JobRunner) keepCancelled(job *model.Job, log logger.Logger) {
+	log.Infof("Job %v was cancelled while it was finishing", job.ID.String())
+	job.Status = model.JobStatusEnum_Cancelled
+	errText := jr.marshallJobError(errCancelledByUser.Error())
+	job.Outcome = &errText
+	jr.ws.JobUpdate(*job)
+}
+
+type JobFunc func(context.Context, *model.Job) error
+
+func (jr *JobRunner) jobFuncResolver(jobType model.JobTypeEnum) (JobFunc, error) {
+	h, ok := Registry.Get(jobType)
+	if !ok {
 		return nil, fmt.Errorf("no implementation to run job type %v", jobType)
 	}
-	return f, nil
+
+	return func(ctx context.Context, j *model.Job) error {
+		return h.Run(jr, ctx, j)
+	}, nil
 }
 
 func (jr *JobRunner) marshallJobError(e string) string {
@@ -206,3 +500,10 @@ func (jr *JobRunner) marshallJobError(e string) string {
 	}
 	return string(data)
 }
+
+// logSkippedJobs reports the jobs that were not created as a job doing the same work is already pending or running
+func (jr *JobRunner) logSkippedJobs(ctx context.Context, skipped []model.Job) {
+	for _, j := range skipped {
+		jr.log(ctx).Infof("Skipped %v job as a job with dedup key %v is already pending or running", j.JobType, *j.DedupKey)
+	}
+}
@@ -0,0 +1,114 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+// jobLogBatchSize is the number of log lines that are buffered before they are written to the job log
+const jobLogBatchSize = 50
+
+// jobLogger writes to the logger of the job runner and keeps info, warning and error messages in the job log of a job.
+// Debug messages are only written to the logger of the job runner
+type jobLogger struct {
+	logger.Logger
+	repo  repository.Repository
+	jobId uuid.UUID
+	mu    sync.Mutex
+	logs  []model.JobLog
+}
+
+func (jr *JobRunner) newJobLogger(job *model.Job) *jobLogger {
+	return &jobLogger{
+		Logger: jr.logger,
+		repo:   jr.repo,
+		jobId:  job.ID,
+		logs:   []model.JobLog{},
+	}
+}
+
+type jobLoggerKey struct{}
+
+func withJobLogger(ctx context.Context, l *jobLogger) context.Context {
+	return context.WithValue(ctx, jobLoggerKey{}, l)
+}
+
+// log returns the logger of the job that is running with the context.
+// Falls back to the logger of the job runner
+func (jr *JobRunner) log(ctx context.Context) logger.Logger {
+	if l, ok := ctx.Value(jobLoggerKey{}).(*jobLogger); ok {
+		return l
+	}
+
+	return jr.logger
+}
+
+func (l *jobLogger) Info(message string) {
+	l.Logger.Info(message)
+	l.record(model.JobLogLevelEnum_Info, message)
+}
+
+func (l *jobLogger) Infof(format string, args ...any) {
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *jobLogger) Warning(message string) {
+	l.Logger.Warning(message)
+	l.record(model.JobLogLevelEnum_Warning, message)
+}
+
+func (l *jobLogger) Warningf(format string, args ...any) {
+	l.Warning(fmt.Sprintf(format, args...))
+}
+
+func (l *jobLogger) Error(message string) {
+	l.Logger.Error(message)
+	l.record(model.JobLogLevelEnum_Error, message)
+}
+
+func (l *jobLogger) Errorf(format string, args ...any) {
+	l.Error(fmt.Sprintf(format, args...))
+}
+
+func (l *jobLogger) record(level model.JobLogLevelEnum, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.logs = append(l.logs, model.JobLog{
+		JobID:   l.jobId,
+		Level:   level,
+		Message: message,
+		Created: time.Now(),
+	})
+
+	if len(l.logs) >= jobLogBatchSize {
+		l.flushLocked()
+	}
+}
+
+// Flush writes the buffered log lines to the job log
+func (l *jobLogger) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.flushLocked()
+}
+
+func (l *jobLogger) flushLocked() {
+	if len(l.logs) == 0 {
+		return
+	}
+
+	if err := l.repo.JobLog().CreateAll(l.logs); err != nil {
+		l.Logger.Warningf("could not write %v lines to the log of job %v: %v", len(l.logs), l.jobId.String(), err.Error())
+	}
+
+	l.logs = []model.JobLog{}
+}
@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/logger"
+	mock_repository "github.com/slugger7/exorcist/internal/mock/repository"
+	mock_jobLogRepository "github.com/slugger7/exorcist/internal/mock/repository/job_log"
+	jobLogRepository "github.com/slugger7/exorcist/internal/repository/job_log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupJobLogger(t *testing.T) (*jobLogger, *mock_jobLogRepository.MockJobLogRepository) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockRepository(ctrl)
+	jobLogRepo := mock_jobLogRepository.NewMockJobLogRepository(ctrl)
+	repo.EXPECT().
+		JobLog().
+		DoAndReturn(func() jobLogRepository.JobLogRepository {
+			return jobLogRepo
+		}).
+		AnyTimes()
+
+	id, _ := uuid.NewRandom()
+	jr := &JobRunner{
+		repo:   repo,
+		logger: logger.New(&environment.EnvironmentVariables{LogLevel: "none"}),
+	}
+
+	return jr.newJobLogger(&model.Job{ID: id}), jobLogRepo
+}
+
+func Test_JobLogger_Flush_ShouldWriteBufferedLogs(t *testing.T) {
+	l, jobLogRepo := setupJobLogger(t)
+
+	jobLogRepo.EXPECT().
+		CreateAll(gomock.Any()).
+		DoAndReturn(func(logs []model.JobLog) error {
+			assert.Len(t, logs, 2)
+			assert.Equal(t, model.JobLogLevelEnum_Warning, logs[0].Level)
+			assert.Equal(t, "could not extract dimensions for some/path", logs[0].Message)
+			assert.Equal(t, model.JobLogLevelEnum_Error, logs[1].Level)
+			assert.Equal(t, l.jobId, logs[1].JobID)
+			return nil
+		}).
+		Times(1)
+
+	l.Debug("not kept in the job log")
+	l.Warningf("could not extract dimensions for %v", "some/path")
+	l.Error("something went wrong")
+	l.Flush()
+	l.Flush()
+}
+
+func Test_JobLogger_FullBuffer_ShouldWriteBeforeFlush(t *testing.T) {
+	l, jobLogRepo := setupJobLogger(t)
+
+	jobLogRepo.EXPECT().
+		CreateAll(gomock.Len(jobLogBatchSize)).
+		Return(nil).
+		Times(1)
+
+	for range jobLogBatchSize {
+		l.Info("scanned a file")
+	}
+
+	assert.Empty(t, l.logs)
+}
+
+func Test_Log_WithoutJobLogger_ShouldUseRunnerLogger(t *testing.T) {
+	jr := &JobRunner{logger: logger.New(&environment.EnvironmentVariables{LogLevel: "none"})}
+
+	assert.Equal(t, jr.logger, jr.log(context.Background()))
+}
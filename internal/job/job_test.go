@@ -0,0 +1,194 @@
+package job
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/logger"
+	mock_repository "github.com/slugger7/exorcist/internal/mock/repository"
+	mock_jobRepository "github.com/slugger7/exorcist/internal/mock/repository/job"
+	mock_jobLogRepository "github.com/slugger7/exorcist/internal/mock/repository/job_log"
+	mock_mediaRepository "github.com/slugger7/exorcist/internal/mock/repository/media"
+	"github.com/slugger7/exorcist/internal/models"
+	jobRepository "github.com/slugger7/exorcist/internal/repository/job"
+	"github.com/slugger7/exorcist/internal/websockets"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_SaturatedJobTypes(t *testing.T) {
+	running := map[model.JobTypeEnum]int{
+		model.JobTypeEnum_GenerateChecksum:  1,
+		model.JobTypeEnum_GenerateThumbnail: 2,
+		model.JobTypeEnum_ScanPath:          3,
+	}
+	limits := map[model.JobTypeEnum]int{
+		model.JobTypeEnum_GenerateChecksum:  1,
+		model.JobTypeEnum_GenerateThumbnail: 4,
+	}
+
+	actual := saturatedJobTypes(running, limits)
+
+	assert.Equal(t, []model.JobTypeEnum{model.JobTypeEnum_GenerateChecksum}, actual)
+}
+
+func Test_SaturatedJobTypes_WithNoLimits_ShouldBeEmpty(t *testing.T) {
+	running := map[model.JobTypeEnum]int{
+		model.JobTypeEnum_GenerateChecksum: 10,
+	}
+
+	actual := saturatedJobTypes(running, map[model.JobTypeEnum]int{})
+
+	assert.Empty(t, actual)
+}
+
+func Test_RetryDelay_DoublesForEveryAttempt(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(30, 1))
+	assert.Equal(t, 60*time.Second, retryDelay(30, 2))
+	assert.Equal(t, 120*time.Second, retryDelay(30, 3))
+}
+
+func Test_RetryDelay_IsCapped(t *testing.T) {
+	assert.Equal(t, maxRetryDelay, retryDelay(30, 20))
+}
+
+func Test_Registry_ShouldRegisterEveryJobType(t *testing.T) {
+	for _, jobType := range model.JobTypeEnumAllValues {
+		if jobType == model.JobTypeEnum_UpdateExistingVideos {
+			continue
+		}
+
+		_, ok := Registry.Get(jobType)
+		assert.True(t, ok, "job type %v is not registered", jobType)
+	}
+}
+
+func Test_ResumableJobTypes_ShouldOnlyContainIdempotentJobTypes(t *testing.T) {
+	expected := []model.JobTypeEnum{
+		model.JobTypeEnum_ScanPath,
+		model.JobTypeEnum_GenerateChecksum,
+		model.JobTypeEnum_GenerateThumbnail,
+		model.JobTypeEnum_RefreshMetadata,
+		model.JobTypeEnum_GenerateFingerprint,
+	}
+
+	assert.Equal(t, expected, resumableJobTypes())
+}
+
+type processJobMocks struct {
+	jr        *JobRunner
+	repo      *mock_repository.MockRepository
+	jobRepo   *mock_jobRepository.MockJobRepository
+	mediaRepo *mock_mediaRepository.MockMediaRepository
+}
+
+func setupProcessJob(t *testing.T) processJobMocks {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockRepository(ctrl)
+	jobRepo := mock_jobRepository.NewMockJobRepository(ctrl)
+	jobLogRepo := mock_jobLogRepository.NewMockJobLogRepository(ctrl)
+	mediaRepo := mock_mediaRepository.NewMockMediaRepository(ctrl)
+	repo.EXPECT().Job().Return(jobRepo).AnyTimes()
+	repo.EXPECT().JobLog().Return(jobLogRepo).AnyTimes()
+	repo.EXPECT().Media().Return(mediaRepo).AnyTimes()
+	jobLogRepo.EXPECT().CreateAll(gomock.Any()).Return(nil).AnyTimes()
+
+	env := &environment.EnvironmentVariables{LogLevel: "none", WorkerId: "test"}
+	jr := &JobRunner{
+		env:         env,
+		repo:        repo,
+		logger:      logger.New(env),
+		ws:          websockets.New(env),
+		shutdownCtx: context.Background(),
+		jobCancels:  map[uuid.UUID]context.CancelCauseFunc{},
+		abandoned:   map[uuid.UUID]bool{},
+	}
+
+	return processJobMocks{jr: jr, repo: repo, jobRepo: jobRepo, mediaRepo: mediaRepo}
+}
+
+func checksumJobFor(t *testing.T) (*model.Job, *models.Media) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, []byte("some video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &models.Media{Media: model.Media{ID: uuid.New(), Path: path}}
+	job, err := CreateGenerateChecksumJob(m.Media.ID, uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	job.ID = uuid.New()
+	job.Attempts = 1
+
+	return job, m
+}
+
+func Test_ProcessJob_WithSuccessfulJob_ShouldComplete(t *testing.T) {
+	mocks := setupProcessJob(t)
+	job, m := checksumJobFor(t)
+
+	mocks.mediaRepo.EXPECT().GetById(m.Media.ID).Return(m, nil)
+	mocks.mediaRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&m.Media, nil)
+	mocks.jobRepo.EXPECT().
+		UpdateJobStatus(gomock.Any()).
+		DoAndReturn(func(j *model.Job) error {
+			assert.Equal(t, model.JobStatusEnum_Completed, j.Status)
+			return nil
+		}).
+		Times(1)
+
+	assert.Nil(t, mocks.jr.processJob(job))
+}
+
+func Test_ProcessJob_WithJobCancelledByUser_ShouldCancel(t *testing.T) {
+	mocks := setupProcessJob(t)
+	job, m := checksumJobFor(t)
+
+	mocks.mediaRepo.EXPECT().
+		GetById(m.Media.ID).
+		DoAndReturn(func(uuid.UUID) (*models.Media, error) {
+			mocks.jr.cancelJobs([]uuid.UUID{job.ID})
+			return m, nil
+		})
+	mocks.mediaRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&m.Media, nil)
+	mocks.jobRepo.EXPECT().
+		UpdateJobStatus(gomock.Any()).
+		DoAndReturn(func(j *model.Job) error {
+			assert.Equal(t, model.JobStatusEnum_Cancelled, j.Status)
+			return nil
+		}).
+		Times(1)
+
+	assert.Nil(t, mocks.jr.processJob(job))
+}
+
+func Test_ProcessJob_WithJobCancelledWhileCompleting_ShouldStayCancelled(t *testing.T) {
+	mocks := setupProcessJob(t)
+	job, m := checksumJobFor(t)
+
+	mocks.mediaRepo.EXPECT().GetById(m.Media.ID).Return(m, nil)
+	mocks.mediaRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&m.Media, nil)
+	mocks.jobRepo.EXPECT().UpdateJobStatus(gomock.Any()).Return(jobRepository.ErrJobNotInProgress).Times(1)
+
+	assert.Nil(t, mocks.jr.processJob(job))
+	assert.Equal(t, model.JobStatusEnum_Cancelled, job.Status)
+}
+
+func Test_ProcessJob_WithJobCancelledWhileFailing_ShouldNotRetry(t *testing.T) {
+	mocks := setupProcessJob(t)
+	job, m := checksumJobFor(t)
+	m.Media.Path = filepath.Join(t.TempDir(), "missing.mp4")
+
+	mocks.mediaRepo.EXPECT().GetById(m.Media.ID).Return(m, nil)
+	mocks.jobRepo.EXPECT().Retry(job, gomock.Any()).Return(jobRepository.ErrJobNotInProgress).Times(1)
+
+	assert.Nil(t, mocks.jr.processJob(job))
+	assert.Equal(t, model.JobStatusEnum_Cancelled, job.Status)
+}
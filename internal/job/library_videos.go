@@ -0,0 +1,104 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+)
+
+// createLibraryVideoJobs creates a child job for every video in the library in batches of the batch size.
+// Videos are the primary media with one of the video extensions that scans of the library pick up
+func (jr *JobRunner) createLibraryVideoJobs(
+	ctx context.Context,
+	job *model.Job,
+	libraryId uuid.UUID,
+	batchSize int,
+	newJob func(mediaId uuid.UUID) (*model.Job, error),
+) error {
+	library, err := jr.repo.Library().GetById(libraryId)
+	if err != nil {
+		return errs.BuildError(err, "could not get library by id: %v", libraryId)
+	}
+	if library == nil {
+		return fmt.Errorf("library not found: %v", libraryId)
+	}
+
+	settings, _, err := newLibraryScanSettings(*library)
+	if err != nil {
+		return err
+	}
+
+	progress := jr.newProgress(job)
+	skip := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return errs.BuildError(err, "stopped before fetching the next batch")
+		}
+
+		batchNr := 1
+		var pageRequest *dto.PageRequestDTO
+		if batchSize != 0 {
+			batchNr = skip/batchSize + 1
+			pageRequest = &dto.PageRequestDTO{
+				Skip:  skip,
+				Limit: batchSize,
+			}
+		}
+
+		jr.log(ctx).Infof("Batch: %v", batchNr)
+
+		mediaPage, err := jr.repo.Media().GetByLibraryId(libraryId, pageRequest, nil)
+		if err != nil {
+			return errs.BuildError(err, "fetching batch of media entities from repo")
+		}
+
+		if len(mediaPage.Data) == 0 {
+			break
+		}
+
+		progress.Report(skip+len(mediaPage.Data), mediaPage.Total, fmt.Sprintf("Batch %v", batchNr))
+
+		var accErr error
+		childJobs := []model.Job{}
+		for _, o := range mediaPage.Data {
+			if o.MediaType != model.MediaTypeEnum_Primary || !hasExtension(o.Path, settings.videoExtensions) {
+				continue
+			}
+
+			j, err := newJob(o.ID)
+			if err != nil {
+				accErr = errors.Join(accErr, err)
+				continue
+			}
+			childJobs = append(childJobs, *j)
+		}
+
+		if accErr != nil {
+			jr.log(ctx).Errorf("encountered errors while processing batch %v: %v", batchNr, accErr.Error())
+		}
+
+		jobs, skipped, err := jr.repo.Job().CreateAll(childJobs)
+		if err != nil {
+			return errs.BuildError(err, "creating child jobs for %v", libraryId)
+		}
+
+		if len(jobs)+len(skipped) != len(childJobs) {
+			return fmt.Errorf("jobs created (%v) and jobs saved to database (%v) differed in batch %v", len(childJobs), len(jobs)+len(skipped), batchNr)
+		}
+
+		jr.logSkippedJobs(ctx, skipped)
+
+		skip = skip + batchSize
+
+		if batchSize == 0 {
+			break
+		}
+	}
+
+	return nil
+}
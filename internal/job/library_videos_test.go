@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	mock_libraryRepository "github.com/slugger7/exorcist/internal/mock/repository/library"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CreateLibraryVideoJobs_ShouldUseTheVideoExtensionsOfTheLibrary(t *testing.T) {
+	m := setupProcessJob(t)
+	libraryRepo := mock_libraryRepository.NewMockLibraryRepository(gomock.NewController(t))
+	m.repo.EXPECT().Library().Return(libraryRepo).AnyTimes()
+
+	scanSettings := `{"videoExtensions":[".mkv"]}`
+	library := model.Library{ID: uuid.New(), ScanSettings: &scanSettings}
+	mkv := model.Media{ID: uuid.New(), Path: "/library/video.mkv", MediaType: model.MediaTypeEnum_Primary}
+	mp4 := model.Media{ID: uuid.New(), Path: "/library/video.mp4", MediaType: model.MediaTypeEnum_Primary}
+	job := &model.Job{ID: uuid.New()}
+
+	libraryRepo.EXPECT().GetById(library.ID).Return(&library, nil).Times(1)
+	m.mediaRepo.EXPECT().
+		GetByLibraryId(library.ID, nil, nil).
+		Return(&dto.PageDTO[model.Media]{Data: []model.Media{mkv, mp4}, Total: 2}, nil).
+		Times(1)
+	m.jobRepo.EXPECT().UpdateProgress(gomock.Any()).Return(nil).AnyTimes()
+	m.jobRepo.EXPECT().
+		CreateAll(gomock.Any()).
+		DoAndReturn(func(jobs []model.Job) ([]model.Job, []model.Job, error) {
+			return jobs, []model.Job{}, nil
+		}).
+		Times(1)
+
+	mediaIds := []uuid.UUID{}
+	err := m.jr.createLibraryVideoJobs(context.Background(), job, library.ID, 0, func(mediaId uuid.UUID) (*model.Job, error) {
+		mediaIds = append(mediaIds, mediaId)
+		return CreateGenerateFingerprintJob(mediaId, &job.ID, false, job.Priority)
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{mkv.ID}, mediaIds)
+}
@@ -0,0 +1,173 @@
+package job
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/models"
+)
+
+// movedMedia matches new files against media of which the file disappeared so that
+// moved and renamed files keep their media along with everything related to it
+type movedMedia struct {
+	bySize  map[int64][]model.Media
+	partial func(path string, algorithm model.ChecksumAlgorithmEnum) (*media.Checksum, error)
+	full    func(path string, algorithm model.ChecksumAlgorithmEnum) (*media.Checksum, error)
+}
+
+// fileChecksums are the checksums that were calculated for a file while matching it
+type fileChecksums struct {
+	partial *media.Checksum
+	full    *media.Checksum
+}
+
+// apply sets the checksums that were calculated on the media
+func (c fileChecksums) apply(m *model.Media) {
+	if c.partial != nil {
+		m.PartialChecksum = &c.partial.Value
+		m.PartialChecksumAlgorithm = &c.partial.Algorithm
+	}
+	if c.full != nil {
+		m.Checksum = &c.full.Value
+		m.ChecksumAlgorithm = &c.full.Algorithm
+	}
+}
+
+func newMovedMedia(missing []model.Media) *movedMedia {
+	m := &movedMedia{
+		bySize:  map[int64][]model.Media{},
+		partial: media.CalculatePartialChecksum,
+		full:    media.CalculateChecksum,
+	}
+	m.add(missing)
+
+	return m
+}
+
+// hasChecksum checks if the media can be matched to a moved file
+func hasChecksum(m model.Media) bool {
+	return m.PartialChecksum != nil || m.Checksum != nil
+}
+
+// add makes the media candidates for files that moved. Media without a checksum can not be matched
+func (m *movedMedia) add(missing []model.Media) {
+	for _, c := range missing {
+		if !hasChecksum(c) {
+			continue
+		}
+
+		m.bySize[c.Size] = append(m.bySize[c.Size], c)
+	}
+}
+
+// match returns the missing media with the same size and checksum as the file.
+// Partial checksums are compared when the media has one. Media that was hashed before partial checksums existed is
+// compared by its full checksum. The checksums of the file are only calculated when there is missing media of the same
+// size and are returned so that they do not have to be calculated again
+func (m *movedMedia) match(file media.File) (*model.Media, fileChecksums, error) {
+	calculated := fileChecksums{}
+	candidates := m.bySize[file.Size]
+	if len(candidates) == 0 {
+		return nil, calculated, nil
+	}
+
+	for i, c := range candidates {
+		var checksum *media.Checksum
+		var err error
+		if c.PartialChecksum != nil {
+			checksum, err = m.checksumOf(file, &calculated.partial, m.partial, algorithmOf(c.PartialChecksumAlgorithm))
+			if err == nil && checksum.Value != *c.PartialChecksum {
+				continue
+			}
+		} else {
+			checksum, err = m.checksumOf(file, &calculated.full, m.full, algorithmOf(c.ChecksumAlgorithm))
+			if err == nil && checksum.Value != *c.Checksum {
+				continue
+			}
+		}
+		if err != nil {
+			return nil, calculated, errs.BuildError(err, "could not calculate checksum of %v", file.Path)
+		}
+
+		m.bySize[file.Size] = append(candidates[:i:i], candidates[i+1:]...)
+		return &c, calculated, nil
+	}
+
+	return nil, calculated, nil
+}
+
+// checksumOf returns the checksum that was already calculated when it has the same algorithm or calculates it
+func (m *movedMedia) checksumOf(
+	file media.File,
+	calculated **media.Checksum,
+	calculate func(path string, algorithm model.ChecksumAlgorithmEnum) (*media.Checksum, error),
+	algorithm model.ChecksumAlgorithmEnum,
+) (*media.Checksum, error) {
+	if *calculated != nil && (*calculated).Algorithm == algorithm {
+		return *calculated, nil
+	}
+
+	checksum, err := calculate(file.Path, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	*calculated = checksum
+
+	return checksum, nil
+}
+
+// algorithmOf defaults to md5 which was the only algorithm before it was recorded
+func algorithmOf(algorithm *model.ChecksumAlgorithmEnum) model.ChecksumAlgorithmEnum {
+	if algorithm == nil {
+		return model.ChecksumAlgorithmEnum_Md5
+	}
+	return *algorithm
+}
+
+// remaining returns the media that was not matched to a moved file
+func (m *movedMedia) remaining(missing []model.Media) []model.Media {
+	remaining := []model.Media{}
+	for _, mm := range missing {
+		if !hasChecksum(mm) || m.isCandidate(mm) {
+			remaining = append(remaining, mm)
+		}
+	}
+
+	return remaining
+}
+
+func (m *movedMedia) isCandidate(mm model.Media) bool {
+	for _, c := range m.bySize[mm.Size] {
+		if c.ID == mm.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// moveMedia points the media to the file it moved to
+func (jr *JobRunner) moveMedia(ctx context.Context, moved model.Media, libPath model.LibraryPath, file media.File) error {
+	from := moved.Path
+
+	moved.Path = file.Path
+	moved.LibraryPathID = libPath.ID
+	moved.Exists = true
+	if _, err := jr.repo.Media().Update(moved, postgres.ColumnList{table.Media.Path, table.Media.LibraryPathID, table.Media.Exists}); err != nil {
+		return errs.BuildError(err, "could not move media %v to %v", moved.ID, file.Path)
+	}
+
+	jr.log(ctx).Infof("Media %v moved from %v to %v", moved.ID, from, file.Path)
+
+	dto := (&dto.MediaOverviewDTO{}).FromModel(models.MediaOverviewModel{
+		Media: moved,
+	})
+	jr.ws.MediaCreate(*dto)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/stretchr/testify/assert"
+)
+
+func checksumOf(checksums map[string]string, calculated *[]string) func(string, model.ChecksumAlgorithmEnum) (*media.Checksum, error) {
+	return func(path string, algorithm model.ChecksumAlgorithmEnum) (*media.Checksum, error) {
+		*calculated = append(*calculated, path)
+		return &media.Checksum{Value: checksums[path], Algorithm: algorithm}, nil
+	}
+}
+
+func Test_MovedMedia_Match_ShouldMatchMissingMediaBySizeAndChecksum(t *testing.T) {
+	checksum := "abc"
+	other := "def"
+	missing := model.Media{ID: uuid.New(), Path: "/old/video.mp4", Size: 10, Checksum: &checksum}
+	sameSize := model.Media{ID: uuid.New(), Path: "/old/other.mp4", Size: 10, Checksum: &other}
+
+	calculated := []string{}
+	moves := newMovedMedia([]model.Media{sameSize, missing})
+	moves.full = checksumOf(map[string]string{"/new/video.mp4": checksum}, &calculated)
+
+	moved, actualChecksums, err := moves.match(media.File{Path: "/new/video.mp4", Size: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, missing.ID, moved.ID)
+	assert.Equal(t, checksum, actualChecksums.full.Value)
+	assert.Equal(t, model.ChecksumAlgorithmEnum_Md5, actualChecksums.full.Algorithm)
+	assert.Len(t, calculated, 1)
+
+	assert.Equal(t, []model.Media{sameSize}, moves.remaining([]model.Media{sameSize, missing}))
+}
+
+func Test_MovedMedia_Match_WithPartialChecksum_ShouldNotCalculateFullChecksum(t *testing.T) {
+	partial := "abc"
+	algorithm := model.ChecksumAlgorithmEnum_Sha256
+	missing := model.Media{ID: uuid.New(), Size: 10, PartialChecksum: &partial, PartialChecksumAlgorithm: &algorithm}
+
+	calculatedPartial, calculatedFull := []string{}, []string{}
+	moves := newMovedMedia([]model.Media{missing})
+	moves.partial = checksumOf(map[string]string{"/new/video.mp4": partial}, &calculatedPartial)
+	moves.full = checksumOf(map[string]string{}, &calculatedFull)
+
+	moved, actualChecksums, err := moves.match(media.File{Path: "/new/video.mp4", Size: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, missing.ID, moved.ID)
+	assert.Equal(t, algorithm, actualChecksums.partial.Algorithm)
+	assert.Nil(t, actualChecksums.full)
+	assert.Empty(t, calculatedFull)
+}
+
+func Test_MovedMedia_Match_WithoutMissingMediaOfTheSameSize_ShouldNotCalculateChecksum(t *testing.T) {
+	checksum := "abc"
+	calculated := []string{}
+	moves := newMovedMedia([]model.Media{{ID: uuid.New(), Size: 10, Checksum: &checksum}})
+	moves.partial = checksumOf(map[string]string{}, &calculated)
+	moves.full = checksumOf(map[string]string{}, &calculated)
+
+	moved, actualChecksums, err := moves.match(media.File{Path: "/new/video.mp4", Size: 11})
+	assert.Nil(t, err)
+	assert.Nil(t, moved)
+	assert.Equal(t, fileChecksums{}, actualChecksums)
+	assert.Empty(t, calculated)
+}
+
+func Test_MovedMedia_Match_ShouldMatchMediaOnlyOnce(t *testing.T) {
+	checksum := "abc"
+	calculated := []string{}
+	moves := newMovedMedia([]model.Media{{ID: uuid.New(), Size: 10, Checksum: &checksum}})
+	moves.full = checksumOf(map[string]string{"/a.mp4": checksum, "/b.mp4": checksum}, &calculated)
+
+	first, _, _ := moves.match(media.File{Path: "/a.mp4", Size: 10})
+	second, _, _ := moves.match(media.File{Path: "/b.mp4", Size: 10})
+
+	assert.NotNil(t, first)
+	assert.Nil(t, second)
+}
+
+func Test_MovedMedia_Remaining_ShouldKeepMediaWithoutChecksum(t *testing.T) {
+	withoutChecksum := model.Media{ID: uuid.New(), Size: 10}
+	moves := newMovedMedia([]model.Media{withoutChecksum})
+
+	assert.Equal(t, []model.Media{withoutChecksum}, moves.remaining([]model.Media{withoutChecksum}))
+}
@@ -0,0 +1,109 @@
+package job
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/slugger7/exorcist/internal/ffmpeg"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/media"
+)
+
+type probeResult[T any] struct {
+	file media.File
+	data T
+	err  error
+}
+
+// probeFiles probes the files with at most the number of workers at the same time.
+// Results are sent in the order that they finish and the channel is closed when all files were probed or the context is done
+func probeFiles[T any](ctx context.Context, files []media.File, workers int, probe func(media.File) (T, error)) <-chan probeResult[T] {
+	queue := make(chan media.File)
+	results := make(chan probeResult[T])
+
+	var wg sync.WaitGroup
+	for range max(min(workers, len(files)), 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range queue {
+				data, err := probe(f)
+				select {
+				case <-ctx.Done():
+					return
+				case results <- probeResult[T]{file: f, data: data, err: err}:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		for _, f := range files {
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- f:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func (jr *JobRunner) scanWorkers() int {
+	if jr.env.ScanWorkers < 1 {
+		return 1
+	}
+	return jr.env.ScanWorkers
+}
+
+type videoProbe struct {
+	width   int
+	height  int
+	runtime float64
+}
+
+// probeVideo reads the dimensions and runtime of a video with ffprobe.
+// Dimensions and runtime that could not be read are left at 0
+func probeVideo(log logger.Logger) func(media.File) (videoProbe, error) {
+	return func(v media.File) (videoProbe, error) {
+		data, err := ffmpeg.UnmarshalledProbe(v.Path)
+		if err != nil {
+			return videoProbe{}, err
+		}
+
+		width, height, err := ffmpeg.GetDimensions(data.Streams)
+		if err != nil {
+			log.Warningf("could not extract dimensions for %v. Setting to 0. Reason: %v", v.Path, err)
+		}
+
+		runtime, err := strconv.ParseFloat(data.Format.Duration, 32)
+		if err != nil {
+			log.Warningf("could not convert duration from string (%v) to float for video %v. Setting runtime to 0. Reason: %v", data.Format.Duration, v.Path, err)
+		}
+
+		return videoProbe{width: width, height: height, runtime: runtime}, nil
+	}
+}
+
+type imageProbe struct {
+	width  int
+	height int
+}
+
+// probeImage reads the dimensions of an image from its header
+func probeImage(i media.File) (imageProbe, error) {
+	width, height, err := media.GetImageDimensions(i.Path)
+	if err != nil {
+		return imageProbe{}, err
+	}
+
+	return imageProbe{width: width, height: height}, nil
+}
@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProbeFiles_ShouldProbeEveryFileWithBoundedWorkers(t *testing.T) {
+	files := []media.File{}
+	for i := range 20 {
+		files = append(files, media.File{Path: fmt.Sprintf("/library/%v.mp4", i)})
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	probe := func(f media.File) (string, error) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return f.Path, nil
+	}
+
+	probed := map[string]bool{}
+	for r := range probeFiles(context.Background(), files, 3, probe) {
+		assert.Nil(t, r.err)
+		assert.Equal(t, r.file.Path, r.data)
+		probed[r.file.Path] = true
+	}
+
+	assert.Len(t, probed, len(files))
+	assert.LessOrEqual(t, maxRunning, 3)
+}
+
+func Test_ProbeFiles_WithCancelledContext_ShouldCloseResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	files := []media.File{{Path: "/library/1.mp4"}, {Path: "/library/2.mp4"}}
+	for range probeFiles(ctx, files, 1, func(f media.File) (string, error) { return f.Path, nil }) {
+	}
+}
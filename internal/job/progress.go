@@ -0,0 +1,82 @@
+package job
+
+import (
+	"sync"
+	"time"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+)
+
+// progressInterval is the minimum time between progress updates of a job
+const progressInterval = time.Second
+
+// jobProgress reports the progress of a running job.
+// Updates are throttled so that neither the database nor the websockets are flooded.
+type jobProgress struct {
+	jr       *JobRunner
+	job      *model.Job
+	mu       sync.Mutex
+	lastSent time.Time
+}
+
+func (jr *JobRunner) newProgress(job *model.Job) *jobProgress {
+	return &jobProgress{
+		jr:  jr,
+		job: job,
+	}
+}
+
+// Report sets the processed and total counts of the job with an optional message
+func (p *jobProgress) Report(processed, total int, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set(processed, total, message)
+}
+
+// AddTotal adds items to the total of the job without resetting the items that were already processed.
+// It is used by jobs that only learn about more of their work while they run
+func (p *jobProgress) AddTotal(items int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set(int(p.job.ProgressProcessed), int(p.job.ProgressTotal)+items, "")
+}
+
+// Increment marks one more item of the job as processed
+func (p *jobProgress) Increment(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set(int(p.job.ProgressProcessed)+1, int(p.job.ProgressTotal), message)
+}
+
+func (p *jobProgress) set(processed, total int, message string) {
+	p.job.ProgressProcessed = int32(processed)
+	p.job.ProgressTotal = int32(total)
+	if message != "" {
+		p.job.ProgressMessage = &message
+	}
+
+	now := time.Now()
+	if !shouldReportProgress(p.lastSent, now, processed, total) {
+		return
+	}
+	p.lastSent = now
+
+	if err := p.jr.repo.Job().UpdateProgress(p.job); err != nil {
+		p.jr.logger.Warningf("could not update progress of job %v: %v", p.job.ID.String(), err.Error())
+	}
+
+	p.jr.ws.JobProgress(*(&dto.JobProgressDTO{}).FromModel(*p.job, now))
+}
+
+// shouldReportProgress throttles progress updates to one per interval. The final update is always reported
+func shouldReportProgress(lastSent, now time.Time, processed, total int) bool {
+	if total > 0 && processed >= total {
+		return true
+	}
+
+	return now.Sub(lastSent) >= progressInterval
+}
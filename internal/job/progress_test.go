@@ -0,0 +1,43 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ShouldReportProgress_WithinInterval_ShouldNotReport(t *testing.T) {
+	now := time.Now()
+
+	assert.False(t, shouldReportProgress(now.Add(-progressInterval/2), now, 5, 10))
+}
+
+func Test_ShouldReportProgress_AfterInterval_ShouldReport(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, shouldReportProgress(now.Add(-progressInterval), now, 5, 10))
+}
+
+func Test_ShouldReportProgress_WhenDone_ShouldAlwaysReport(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, shouldReportProgress(now, now, 10, 10))
+}
+
+func Test_AddTotal_ShouldKeepTheProcessedItems(t *testing.T) {
+	mocks := setupProcessJob(t)
+	mocks.jobRepo.EXPECT().UpdateProgress(gomock.Any()).Return(nil).AnyTimes()
+	job := &model.Job{}
+	progress := mocks.jr.newProgress(job)
+
+	progress.AddTotal(2)
+	progress.Increment("image.jpg")
+	progress.Increment("image.png")
+	progress.AddTotal(3)
+
+	assert.Equal(t, int32(2), job.ProgressProcessed)
+	assert.Equal(t, int32(5), job.ProgressTotal)
+}
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"time"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+// resumableJobTypes are the job types that are safe to run again after they were interrupted
+func resumableJobTypes() []model.JobTypeEnum {
+	jobTypes := []model.JobTypeEnum{}
+	for _, t := range Registry.Types() {
+		if h, _ := Registry.Get(t); h.Idempotent {
+			jobTypes = append(jobTypes, t)
+		}
+	}
+
+	return jobTypes
+}
+
+func heartbeatTimeout(env *environment.EnvironmentVariables) time.Duration {
+	return time.Duration(env.JobHeartbeatTimeout) * time.Second
+}
+
+// RecoverInterrupted handles the jobs that were still in progress when this worker stopped and
+// the jobs of other workers that stopped sending heartbeats.
+func RecoverInterrupted(env *environment.EnvironmentVariables, repo repository.Repository, logger logger.Logger) {
+	resumed, cancelled := recoverInterrupted(repo, logger, env.WorkerId, time.Now().Add(-heartbeatTimeout(env)))
+
+	logger.Infof("Resumed %v and cancelled %v interrupted jobs", len(resumed), len(cancelled))
+}
+
+// recoverInterrupted puts idempotent jobs back in the queue and cancels the rest
+func recoverInterrupted(repo repository.Repository, logger logger.Logger, worker string, expiredBefore time.Time) ([]model.Job, []model.Job) {
+	resumed, err := repo.Job().ResumeInprogress(resumableJobTypes(), worker, expiredBefore)
+	if err != nil {
+		logger.Errorf("resuming interrupted jobs: %v", err.Error())
+	}
+
+	for _, j := range resumed {
+		logger.Infof("Resumed interrupted %v job %v", j.JobType, j.ID.String())
+	}
+
+	cancelled, err := repo.Job().CancelInprogress(worker, expiredBefore)
+	if err != nil {
+		logger.Errorf("cancelling interrupted jobs: %v", err.Error())
+	}
+
+	for _, j := range cancelled {
+		logger.Warningf("Cancelled interrupted %v job %v as it is not safe to run again", j.JobType, j.ID.String())
+	}
+
+	return resumed, cancelled
+}
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,16 +9,44 @@ import (
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/dto"
 	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
+	"github.com/slugger7/exorcist/internal/repository"
 )
 
-func (jr *JobRunner) refreshLibraryMetadata(job *model.Job) error {
+var refreshLibraryMetadataJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.RefreshLibraryMetadata]{
+	Type:     model.JobTypeEnum_RefreshLibraryMetadata,
+	Priority: dto.JobPriority_Medium,
+	Defaults: func(d *dto.RefreshLibraryMetadata) {
+		if d.RefreshFields == nil {
+			d.RefreshFields = &dto.RefreshFields{
+				Size:     true,
+				Checksum: false,
+			}
+		}
+	},
+	Validate: func(repo repository.Repository, d dto.RefreshLibraryMetadata) error {
+		_, err := validateLibrary(repo, d.LibraryId)
+		return err
+	},
+	DedupKey: func(d dto.RefreshLibraryMetadata) *string {
+		return jobRegistry.Key(model.JobTypeEnum_RefreshLibraryMetadata, d.LibraryId)
+	},
+	Run: (*JobRunner).refreshLibraryMetadata,
+})
+
+func (jr *JobRunner) refreshLibraryMetadata(ctx context.Context, job *model.Job) error {
 	var jobData dto.RefreshLibraryMetadata
 	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
 		return errs.BuildError(err, "error parsing job data for refresh library metadata: %v", job.Data)
 	}
 
+	progress := jr.newProgress(job)
 	skip := 0
 	for {
+		if err := ctx.Err(); err != nil {
+			return errs.BuildError(err, "stopped before fetching the next batch")
+		}
+
 		batchNr := 1
 		var pageRequest *dto.PageRequestDTO
 		if jobData.BatchSize != 0 {
@@ -28,7 +57,7 @@ func (jr *JobRunner) refreshLibraryMetadata(job *model.Job) error {
 			}
 		}
 
-		jr.logger.Infof("Batch: %v", batchNr)
+		jr.log(ctx).Infof("Batch: %v", batchNr)
 
 		mediaPage, err := jr.repo.Media().GetByLibraryId(jobData.LibraryId, pageRequest, nil)
 		if err != nil {
@@ -39,6 +68,8 @@ func (jr *JobRunner) refreshLibraryMetadata(job *model.Job) error {
 			break
 		}
 
+		progress.Report(skip+len(mediaPage.Data), mediaPage.Total, fmt.Sprintf("Batch %v", batchNr))
+
 		var accErr error
 		refreshJobs := []model.Job{}
 		for _, o := range mediaPage.Data {
@@ -51,18 +82,20 @@ func (jr *JobRunner) refreshLibraryMetadata(job *model.Job) error {
 		}
 
 		if accErr != nil {
-			jr.logger.Errorf("encountered errors while processing batch %v: %v", batchNr, accErr.Error())
+			jr.log(ctx).Errorf("encountered errors while processing batch %v: %v", batchNr, accErr.Error())
 		}
 
-		jobs, err := jr.repo.Job().CreateAll(refreshJobs)
+		jobs, skipped, err := jr.repo.Job().CreateAll(refreshJobs)
 		if err != nil {
 			return errs.BuildError(err, "creating refresh metadata jobs for %v", jobData.LibraryId)
 		}
 
-		if len(jobs) != len(refreshJobs) {
-			return fmt.Errorf("jobs created (%v) and jobs saved to database (%v) differed in batch %v", len(refreshJobs), len(jobs), batchNr)
+		if len(jobs)+len(skipped) != len(refreshJobs) {
+			return fmt.Errorf("jobs created (%v) and jobs saved to database (%v) differed in batch %v", len(refreshJobs), len(jobs)+len(skipped), batchNr)
 		}
 
+		jr.logSkippedJobs(ctx, skipped)
+
 		skip = skip + jobData.BatchSize
 
 		if jobData.BatchSize == 0 {
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -10,41 +11,50 @@ import (
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
 	"github.com/slugger7/exorcist/internal/dto"
 	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/repository"
 )
 
-func CreateRefreshMetadataJob(media model.Media, jobId *uuid.UUID, refreshFields *dto.RefreshFields) (*model.Job, error) {
-	localRefreshFields := *refreshFields
-	if refreshFields == nil {
-		localRefreshFields = dto.RefreshFields{
-			Size:     true,
-			Checksum: false,
+var refreshMetadataJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.RefreshMetadata]{
+	Type:        model.JobTypeEnum_RefreshMetadata,
+	Priority:    dto.JobPriority_Low,
+	MaxAttempts: 3,
+	Idempotent:  true,
+	Defaults: func(d *dto.RefreshMetadata) {
+		if d.RefreshFields == nil {
+			d.RefreshFields = &dto.RefreshFields{
+				Size:     true,
+				Checksum: false,
+			}
 		}
-	}
+	},
+	Validate: func(repo repository.Repository, d dto.RefreshMetadata) error {
+		_, err := validateMedia(repo, d.MediaId)
+		return err
+	},
+	// the fields are not part of the key so that refreshing some fields does not run next to refreshing others
+	DedupKey: func(d dto.RefreshMetadata) *string {
+		return jobRegistry.Key(model.JobTypeEnum_RefreshMetadata, d.MediaId)
+	},
+	Run: (*JobRunner).RefreshMetadata,
+})
 
+func CreateRefreshMetadataJob(media model.Media, jobId *uuid.UUID, refreshFields *dto.RefreshFields) (*model.Job, error) {
 	d := dto.RefreshMetadata{
 		MediaId:       media.ID,
-		RefreshFields: &localRefreshFields,
+		RefreshFields: refreshFields,
 	}
 
-	js, err := json.Marshal(d)
+	job, err := refreshMetadataJob.NewJob(d, jobId)
 	if err != nil {
-		return nil, errs.BuildError(err, "could not marshal refresh metadata dto")
-	}
-
-	data := string(js)
-	job := &model.Job{
-		JobType:  model.JobTypeEnum_RefreshMetadata,
-		Status:   model.JobStatusEnum_NotStarted,
-		Data:     &data,
-		Parent:   jobId,
-		Priority: dto.JobPriority_Low,
+		return nil, errs.BuildError(err, "could not create refresh metadata job for: %v", media.ID)
 	}
 
 	return job, nil
 }
 
-func (jr *JobRunner) RefreshMetadata(job *model.Job) error {
+func (jr *JobRunner) RefreshMetadata(ctx context.Context, job *model.Job) error {
 	var jobData dto.RefreshMetadata
 	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
 		return errs.BuildError(err, "error parsing job data for refresh metadata: %v", job.Data)
@@ -74,14 +84,29 @@ func (jr *JobRunner) RefreshMetadata(job *model.Job) error {
 	}
 
 	if jobData.RefreshFields.Checksum {
-		checksum, err := media.CalculateMD5(mediaEntity.Path)
+		algorithm := jr.checksumAlgorithm()
+		partial, err := media.CalculatePartialChecksum(mediaEntity.Path, algorithm)
 		if err != nil {
-			return errs.BuildError(err, "calculating md5sum for %v", mediaEntity.Path)
+			return errs.BuildError(err, "calculating partial %v checksum for %v", algorithm, mediaEntity.Path)
+		}
+		if mediaEntity.PartialChecksum == nil || partial.Value != *mediaEntity.PartialChecksum {
+			mediaEntity.PartialChecksum = &partial.Value
+			mediaEntity.PartialChecksumAlgorithm = &partial.Algorithm
+
+			updateColumns = append(updateColumns, table.Media.PartialChecksum, table.Media.PartialChecksumAlgorithm)
 		}
-		if mediaEntity.Media.Checksum == nil || checksum != *mediaEntity.Media.Checksum {
-			mediaEntity.Media.Checksum = &checksum
 
-			updateColumns = append(updateColumns, table.Media.Checksum)
+		if jr.env != nil && jr.env.FullChecksum {
+			checksum, err := media.CalculateChecksum(mediaEntity.Path, algorithm)
+			if err != nil {
+				return errs.BuildError(err, "calculating %v checksum for %v", algorithm, mediaEntity.Path)
+			}
+			if mediaEntity.Media.Checksum == nil || checksum.Value != *mediaEntity.Media.Checksum {
+				mediaEntity.Media.Checksum = &checksum.Value
+				mediaEntity.Media.ChecksumAlgorithm = &checksum.Algorithm
+
+				updateColumns = append(updateColumns, table.Media.Checksum, table.Media.ChecksumAlgorithm)
+			}
 		}
 	}
 
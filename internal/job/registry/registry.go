@@ -0,0 +1,202 @@
+package jobRegistry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+// Definition describes a job type with a payload of type P that is run by a runner of type R
+type Definition[R any, P any] struct {
+	Type model.JobTypeEnum
+	// Priority is used for jobs that are created without a priority
+	Priority dto.JobPriority
+	// MaxAttempts is the number of times a job is attempted before it fails. Jobs are attempted once when it is not set
+	MaxAttempts int
+	// Idempotent flags job types that are safe to run again after they were interrupted.
+	// Job types that create child jobs are only idempotent when running them again does not duplicate their children.
+	// Scans of a path are idempotent as they skip media that already exists and their child jobs are deduplicated by their DedupKey
+	Idempotent bool
+	// Defaults sets the optional fields of the payload that were not set
+	Defaults func(payload *P)
+	// Validate checks the payload of a job before it is created
+	Validate func(repo repository.Repository, payload P) error
+	// DedupKey identifies the work a job does. Two jobs with the same key that are pending or running would do
+	// the same work so only the first one is created. Jobs are never deduplicated when it is not set or returns nil
+	DedupKey func(payload P) *string
+	// Run runs a job of this type
+	Run func(runner R, ctx context.Context, job *model.Job) error
+}
+
+// Handler is a registered job type
+type Handler[R any] struct {
+	Type        model.JobTypeEnum
+	Payload     reflect.Type
+	Priority    dto.JobPriority
+	MaxAttempts int
+	Idempotent  bool
+	Run         func(runner R, ctx context.Context, job *model.Job) error
+	build       func(repo repository.Repository, data map[string]any) (*model.Job, error)
+}
+
+// Builder validates and builds jobs of the registered job types
+type Builder interface {
+	Build(repo repository.Repository, m dto.CreateJobDTO) (*model.Job, error)
+	Types() []model.JobTypeEnum
+}
+
+type Registry[R any] struct {
+	handlers map[model.JobTypeEnum]*Handler[R]
+}
+
+func New[R any]() *Registry[R] {
+	return &Registry[R]{
+		handlers: map[model.JobTypeEnum]*Handler[R]{},
+	}
+}
+
+// Register adds the job type to the registry.
+// The definition is returned so that it can be used to create jobs of the type
+func Register[R any, P any](r *Registry[R], d Definition[R, P]) *Definition[R, P] {
+	if _, ok := r.handlers[d.Type]; ok {
+		panic(fmt.Sprintf("job type %v is already registered", d.Type))
+	}
+
+	r.handlers[d.Type] = &Handler[R]{
+		Type:        d.Type,
+		Payload:     reflect.TypeFor[P](),
+		Priority:    d.Priority,
+		MaxAttempts: d.MaxAttempts,
+		Idempotent:  d.Idempotent,
+		Run:         d.Run,
+		build: func(repo repository.Repository, data map[string]any) (*model.Job, error) {
+			return d.build(repo, data)
+		},
+	}
+
+	return &d
+}
+
+// Get returns the handler of the job type
+func (r *Registry[R]) Get(jobType model.JobTypeEnum) (*Handler[R], bool) {
+	h, ok := r.handlers[jobType]
+	return h, ok
+}
+
+// Types returns the registered job types in the order of the job type enum
+func (r *Registry[R]) Types() []model.JobTypeEnum {
+	jobTypes := []model.JobTypeEnum{}
+	for _, t := range model.JobTypeEnumAllValues {
+		if _, ok := r.handlers[t]; ok {
+			jobTypes = append(jobTypes, t)
+		}
+	}
+
+	return jobTypes
+}
+
+// Payloads returns the payload types of the registered job types in the order of the job type enum
+func (r *Registry[R]) Payloads() []reflect.Type {
+	payloads := []reflect.Type{}
+	for _, t := range r.Types() {
+		if p := r.handlers[t].Payload; !slices.Contains(payloads, p) {
+			payloads = append(payloads, p)
+		}
+	}
+
+	return payloads
+}
+
+// Build implements Builder.
+// Sets the defaults of the payload and validates it. Returns the job that would be created without persisting it
+func (r *Registry[R]) Build(repo repository.Repository, m dto.CreateJobDTO) (*model.Job, error) {
+	h, ok := r.handlers[m.Type]
+	if !ok {
+		return nil, fmt.Errorf("job type not implemented: %v", m.Type)
+	}
+
+	job, err := h.build(repo, m.Data)
+	if err != nil {
+		return nil, errs.BuildError(err, "error encountered while creating %v job", m.Type)
+	}
+
+	if m.Priority != nil {
+		job.Priority = *m.Priority
+	}
+
+	return job, nil
+}
+
+func (d *Definition[R, P]) build(repo repository.Repository, data map[string]any) (*model.Job, error) {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not marshal data field")
+	}
+
+	var payload P
+	if err := json.Unmarshal(bytes, &payload); err != nil {
+		return nil, errs.BuildError(err, "could not unmarshal data for %v job: %v", d.Type, string(bytes))
+	}
+
+	if d.Defaults != nil {
+		d.Defaults(&payload)
+	}
+
+	if d.Validate != nil {
+		if err := d.Validate(repo, payload); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.job(payload, nil)
+}
+
+// job creates a job with the payload of which the defaults were already set
+func (d *Definition[R, P]) job(payload P, parent *uuid.UUID) (*model.Job, error) {
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not marshal %v data", d.Type)
+	}
+	data := string(bytes)
+
+	var dedupKey *string
+	if d.DedupKey != nil {
+		dedupKey = d.DedupKey(payload)
+	}
+
+	return &model.Job{
+		JobType:  d.Type,
+		Status:   model.JobStatusEnum_NotStarted,
+		Data:     &data,
+		Parent:   parent,
+		Priority: d.Priority,
+		DedupKey: dedupKey,
+	}, nil
+}
+
+// NewJob creates a job of this type that is not validated. Used by jobs that create child jobs
+func (d *Definition[R, P]) NewJob(payload P, parent *uuid.UUID) (*model.Job, error) {
+	if d.Defaults != nil {
+		d.Defaults(&payload)
+	}
+
+	return d.job(payload, parent)
+}
+
+// Key joins the job type and the parts that identify the work of a job into a dedup key
+func Key(jobType model.JobTypeEnum, parts ...any) *string {
+	key := jobType.String()
+	for _, p := range parts {
+		key = fmt.Sprintf("%v:%v", key, p)
+	}
+
+	return &key
+}
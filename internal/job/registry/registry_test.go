@@ -0,0 +1,112 @@
+package jobRegistry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRunner struct{}
+
+type testPayload struct {
+	MediaId  uuid.UUID `json:"mediaId"`
+	Interval float64   `json:"interval"`
+}
+
+func setupRegistry() (*Registry[testRunner], *Definition[testRunner, testPayload]) {
+	r := New[testRunner]()
+	d := Register(r, Definition[testRunner, testPayload]{
+		Type:     model.JobTypeEnum_GenerateChapters,
+		Priority: dto.JobPriority_Low,
+		Defaults: func(p *testPayload) {
+			if p.Interval == 0 {
+				p.Interval = 300
+			}
+		},
+		Validate: func(repo repository.Repository, p testPayload) error {
+			if p.MediaId == uuid.Nil {
+				return fmt.Errorf("media id is required")
+			}
+			return nil
+		},
+		DedupKey: func(p testPayload) *string {
+			return Key(model.JobTypeEnum_GenerateChapters, p.MediaId)
+		},
+		Run: func(runner testRunner, ctx context.Context, job *model.Job) error {
+			return nil
+		},
+	})
+
+	return r, d
+}
+
+func Test_Build_ShouldSetDefaultsAndPriority(t *testing.T) {
+	r, _ := setupRegistry()
+	id, _ := uuid.NewRandom()
+
+	actual, err := r.Build(nil, dto.CreateJobDTO{
+		Type: model.JobTypeEnum_GenerateChapters,
+		Data: map[string]any{"mediaId": id.String()},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, model.JobTypeEnum_GenerateChapters, actual.JobType)
+	assert.Equal(t, model.JobStatusEnum_NotStarted, actual.Status)
+	assert.Equal(t, dto.JobPriority_Low, actual.Priority)
+	assert.Equal(t, fmt.Sprintf(`{"mediaId":"%v","interval":300}`, id), *actual.Data)
+	assert.Equal(t, fmt.Sprintf("generate_chapters:%v", id), *actual.DedupKey)
+}
+
+func Test_Build_WithInvalidPayload_ShouldReturnError(t *testing.T) {
+	r, _ := setupRegistry()
+
+	_, err := r.Build(nil, dto.CreateJobDTO{
+		Type: model.JobTypeEnum_GenerateChapters,
+		Data: map[string]any{},
+	})
+
+	assert.NotNil(t, err)
+}
+
+func Test_Build_WithUnregisteredType_ShouldReturnError(t *testing.T) {
+	r, _ := setupRegistry()
+
+	_, err := r.Build(nil, dto.CreateJobDTO{Type: model.JobTypeEnum_ScanPath})
+
+	assert.NotNil(t, err)
+}
+
+func Test_NewJob_ShouldSetDefaultsWithoutValidating(t *testing.T) {
+	_, d := setupRegistry()
+	parent, _ := uuid.NewRandom()
+
+	actual, err := d.NewJob(testPayload{}, &parent)
+	assert.Nil(t, err)
+
+	assert.Equal(t, &parent, actual.Parent)
+	assert.Equal(t, fmt.Sprintf(`{"mediaId":"%v","interval":300}`, uuid.Nil), *actual.Data)
+}
+
+func Test_Register_Twice_ShouldPanic(t *testing.T) {
+	r, _ := setupRegistry()
+
+	assert.Panics(t, func() {
+		Register(r, Definition[testRunner, testPayload]{Type: model.JobTypeEnum_GenerateChapters})
+	})
+}
+
+func Test_NewJob_WithoutDedupKey_ShouldNotBeDeduplicated(t *testing.T) {
+	r := New[testRunner]()
+	d := Register(r, Definition[testRunner, testPayload]{Type: model.JobTypeEnum_UpdateExistingVideos})
+
+	actual, err := d.NewJob(testPayload{}, nil)
+	assert.Nil(t, err)
+
+	assert.Nil(t, actual.DedupKey)
+}
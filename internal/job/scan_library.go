@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+var scanLibraryJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.ScanLibraryData]{
+	Type:     model.JobTypeEnum_ScanLibrary,
+	Priority: dto.JobPriority_Medium,
+	Validate: func(repo repository.Repository, d dto.ScanLibraryData) error {
+		_, err := validateLibrary(repo, d.LibraryId)
+		return err
+	},
+	DedupKey: func(d dto.ScanLibraryData) *string {
+		return jobRegistry.Key(model.JobTypeEnum_ScanLibrary, d.LibraryId)
+	},
+	Run: (*JobRunner).scanLibrary,
+})
+
+func (jr *JobRunner) scanLibrary(ctx context.Context, job *model.Job) error {
+	var jobData dto.ScanLibraryData
+	if err := json.Unmarshal([]byte(*job.Data), &jobData); err != nil {
+		return errs.BuildError(err, "error parsing job data for scan library: %v", job.Data)
+	}
+
+	libraryPaths, err := jr.repo.LibraryPath().GetByLibraryId(jobData.LibraryId)
+	if err != nil {
+		return errs.BuildError(err, "could not get library paths for library: %v", jobData.LibraryId.String())
+	}
+
+	if len(libraryPaths) == 0 {
+		jr.log(ctx).Infof("no library paths found for library %v. Nothing to scan", jobData.LibraryId.String())
+		return nil
+	}
+
+	var accErr error
+	scanPathJobs := []model.Job{}
+	for _, l := range libraryPaths {
+		j, err := CreateScanPathJob(l.ID, &job.ID, job.Priority)
+		if err != nil {
+			accErr = errors.Join(accErr, err)
+			continue
+		}
+		scanPathJobs = append(scanPathJobs, *j)
+	}
+
+	if accErr != nil {
+		jr.log(ctx).Errorf("encountered errors while creating scan path jobs: %v", accErr.Error())
+	}
+
+	jobs, skipped, err := jr.repo.Job().CreateAll(scanPathJobs)
+	if err != nil {
+		return errs.BuildError(err, "creating scan path jobs for library %v", jobData.LibraryId.String())
+	}
+
+	if len(jobs)+len(skipped) != len(scanPathJobs) {
+		return fmt.Errorf("jobs created (%v) and jobs saved to database (%v) differed", len(scanPathJobs), len(jobs)+len(skipped))
+	}
+
+	jr.logSkippedJobs(ctx, skipped)
+
+	return nil
+}
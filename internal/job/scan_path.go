@@ -1,45 +1,162 @@
 package job
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"os"
 	"path/filepath"
 	"slices"
-	"strconv"
+	"strings"
 
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
 	"github.com/slugger7/exorcist/internal/dto"
 	errs "github.com/slugger7/exorcist/internal/errors"
 	"github.com/slugger7/exorcist/internal/ffmpeg"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/media"
 	"github.com/slugger7/exorcist/internal/models"
+	"github.com/slugger7/exorcist/internal/repository"
 )
 
+var scanPathJob = jobRegistry.Register(Registry, jobRegistry.Definition[*JobRunner, dto.ScanPathData]{
+	Type:        model.JobTypeEnum_ScanPath,
+	Priority:    dto.JobPriority_Medium,
+	MaxAttempts: 3,
+	Idempotent:  true,
+	Validate: func(repo repository.Repository, d dto.ScanPathData) error {
+		libPath, err := validateLibraryPath(repo, d.LibraryPathId)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range d.Paths {
+			if !media.IsInPath(libPath.Path, p) {
+				return fmt.Errorf("path %v is not in library path %v", p, libPath.Path)
+			}
+		}
+
+		return nil
+	},
+	// dry runs, full scans and scans of some of the paths do different work than a scan of the whole library path
+	DedupKey: func(d dto.ScanPathData) *string {
+		parts := []any{d.LibraryPathId}
+		if d.DryRun {
+			parts = append(parts, "dry_run")
+		}
+		if d.Full {
+			parts = append(parts, "full")
+		}
+		if len(d.Paths) > 0 {
+			parts = append(parts, pathsHash(d.Paths))
+		}
+		return jobRegistry.Key(model.JobTypeEnum_ScanPath, parts...)
+	},
+	Run: (*JobRunner).ScanPath,
+})
+
+// videoExtensions and imageExtensions are picked up by scans of libraries that do not set their own
 var videoExtensions = [...]string{".mp4", ".m4v", ".mkv", ".avi", ".wmv", ".flv", ".webm", ".f4v", ".mpg", ".m2ts", ".mov"}
 var imageExtensions = [...]string{".jpg", ".png", ".webp"}
 
 const batchSize = 100
 
-func (jr *JobRunner) getFilesByExtension(path string, extensions []string, ch chan []media.File) {
+// filesOnDisk are the files that were found in the roots of a scan and the number of files that each ignore file excluded.
+// The error is set when one of the roots could not be walked in which case the files are incomplete
+type filesOnDisk struct {
+	files   []media.File
+	ignored map[string]int
+	err     error
+}
+
+func (jr *JobRunner) getFilesByExtension(ctx context.Context, roots []string, extensions []string, exclude *media.Exclude, ch chan filesOnDisk) {
 	defer jr.wg.Done()
 
 	select {
-	case <-jr.shutdownCtx.Done():
-		jr.logger.Debugf("Shutdown context called")
+	case <-ctx.Done():
+		jr.log(ctx).Debugf("Shutdown context called")
 		return
 	default:
-		values, err := media.GetFilesByExtensions(path, extensions)
-		if err != nil {
-			jr.logger.Errorf("could not get files by extension: %v", err)
-			ch <- nil
+		values := filesOnDisk{files: []media.File{}, ignored: map[string]int{}}
+		for _, root := range roots {
+			files, ignored, err := media.GetFilesByExtensions(root, extensions, exclude)
+			if err != nil {
+				jr.log(ctx).Errorf("could not get files by extension: %v", err)
+				ch <- filesOnDisk{err: errs.BuildError(err, "could not walk %v", root)}
+				return
+			}
+			values.files = append(values.files, files...)
+			addIgnored(values.ignored, ignored)
 		}
 		ch <- values
 	}
 
 }
 
-func (jr *JobRunner) ScanPath(job *model.Job) error {
+func CreateScanPathJob(libraryPathId uuid.UUID, jobId *uuid.UUID, priority int16) (*model.Job, error) {
+	job, err := scanPathJob.NewJob(dto.ScanPathData{LibraryPathId: libraryPathId}, jobId)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not create scan path job for: %v", libraryPathId)
+	}
+
+	job.Priority = priority
+
+	return job, nil
+}
+
+// CreateScanFilesJob creates a scan path job that only scans the files and directories
+// instead of walking the whole library path
+func CreateScanFilesJob(libraryPathId uuid.UUID, paths []string) (*model.Job, error) {
+	job, err := scanPathJob.NewJob(dto.ScanPathData{LibraryPathId: libraryPathId, Paths: paths}, nil)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not create scan files job for: %v", libraryPathId)
+	}
+
+	return job, nil
+}
+
+// scanRoots are the paths that a scan path job looks for media in and the ones of those that should be walked.
+// Paths of a targeted scan that no longer exist are not walked as they only have media to mark as missing.
+// A changed ignore file scans the directory that it is in as it can exclude or include any file in it
+func scanRoots(libPath model.LibraryPath, data dto.ScanPathData) ([]string, []string) {
+	if len(data.Paths) == 0 {
+		return []string{libPath.Path}, []string{libPath.Path}
+	}
+
+	paths := []string{}
+	for _, p := range data.Paths {
+		if filepath.Base(p) == media.IgnoreFileName {
+			p = filepath.Dir(p)
+		}
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+
+	// paths in another one of the paths would be walked twice
+	roots := []string{}
+	walk := []string{}
+	for _, p := range paths {
+		if slices.ContainsFunc(roots, func(r string) bool { return media.IsInPath(r, p) }) {
+			continue
+		}
+
+		roots = append(roots, p)
+		if _, err := os.Stat(p); err == nil {
+			walk = append(walk, p)
+		}
+	}
+
+	return roots, walk
+}
+
+func (jr *JobRunner) ScanPath(ctx context.Context, job *model.Job) error {
 	var data dto.ScanPathData
 	if err := json.Unmarshal([]byte(*job.Data), &data); err != nil {
 		return errs.BuildError(err, "could not unmarshal scan path job data: %v", err)
@@ -53,169 +170,598 @@ func (jr *JobRunner) ScanPath(job *model.Job) error {
 		return fmt.Errorf("library path not found: %v", data.LibraryPathId)
 	}
 
-	videoChan := make(chan []media.File)
+	library, err := jr.repo.Library().GetById(libPath.LibraryID)
+	if err != nil {
+		return errs.BuildError(err, "could not get library of library path: %v", libPath.ID)
+	}
+
+	settings, err := newScanSettings(*library, *libPath)
+	if err != nil {
+		return err
+	}
+
+	roots, walkRoots := scanRoots(*libPath, data)
+
+	// the channels are buffered so that the walks do not block on their send when the scan returns early
+	videoChan := make(chan filesOnDisk, 1)
 	jr.wg.Add(1)
-	go jr.getFilesByExtension(libPath.Path, videoExtensions[:], videoChan)
+	go jr.getFilesByExtension(ctx, walkRoots, settings.videoExtensions, settings.exclude, videoChan)
 
-	imageChan := make(chan []media.File)
+	imageChan := make(chan filesOnDisk, 1)
 	jr.wg.Add(1)
-	go jr.getFilesByExtension(libPath.Path, imageExtensions[:], imageChan)
+	go jr.getFilesByExtension(ctx, walkRoots, settings.imageExtensions, settings.exclude, imageChan)
 
 	existingMedia, err := jr.repo.Media().GetByLibraryPathId(libPath.ID)
 	if err != nil {
 		return errs.BuildError(err, "could not get existing videos for library path: %v", libPath.ID)
 	}
+	existingMedia = filterMediaInPaths(existingMedia, roots)
 
-	for range 2 { // need to connsume off of each channel once
-		select {
-		case <-jr.shutdownCtx.Done():
-			const msg string = "shutdown signal received. stopping"
-			jr.logger.Warning(msg)
-			return errors.New(msg)
-		case imagesOnDisk := <-imageChan:
-			_ = imagesOnDisk
-			// TODO: handle images on disk
-			continue
-		case videosOnDisk := <-videoChan:
-			err := jr.handleVideosOnDisk(*job, *libPath, existingMedia, videosOnDisk)
-			if err != nil {
-				continue // TODO: concat errors to bigger errors object to return
-			}
-		}
+	missingMedia, err := jr.repo.Media().GetMissing()
+	if err != nil {
+		return errs.BuildError(err, "could not get missing media")
 	}
-	return nil
-}
-
-func (jr *JobRunner) handleVideosOnDisk(job model.Job, libPath model.LibraryPath, existingMedia []model.Media, videosOnDisk []media.File) error {
-	nonExistentMedia := media.FindNonExistentMedia(existingMedia, videosOnDisk)
-	if len(nonExistentMedia) > 0 {
-		jr.removeMedia(nonExistentMedia)
+	moves := newMovedMedia(missingMedia)
+
+	report := dto.ScanPathReport{
+		New:           []dto.ScanPathReportFile{},
+		Missing:       []dto.ScanPathReportMedia{},
+		Changed:       []dto.ScanPathReportFile{},
+		Moved:         []dto.ScanPathReportMove{},
+		ProbeFailures: []dto.ScanPathReportFailure{},
+		Ignored:       []dto.ScanPathReportIgnore{},
 	}
-
+	ignored := map[string]int{}
 	accErrs := []error{}
-	for _, v := range videosOnDisk {
+	// the files of both kinds of media count towards the progress of the job as it is only known how many files of a
+	// kind have to be probed once the walk for them finished
+	progress := jr.newProgress(job)
+
+	for range 2 { // need to connsume off of each channel once
 		select {
-		case <-jr.shutdownCtx.Done():
-			return fmt.Errorf("partially done, ended due to shutdown")
-		default:
-			if mediaExists(existingMedia, v.Path) {
+		case <-ctx.Done():
+			const msg string = "job cancelled or shutdown signal received. stopping"
+			jr.log(ctx).Warning(msg)
+			return errors.New(msg)
+		case onDisk := <-imageChan:
+			// media would be marked as missing for every file that was not found
+			if onDisk.err != nil {
+				accErrs = append(accErrs, onDisk.err)
 				continue
 			}
 
-			data, err := ffmpeg.UnmarshalledProbe(v.Path)
-			if err != nil {
-				accErrs = append(accErrs, errs.BuildError(err, "could not get unmarshalled probe data: %v", v.Path))
+			addIgnored(ignored, onDisk.ignored)
+			existingImages := filterMediaByExtensions(existingMedia, settings.imageExtensions)
+			if data.DryRun {
+				dryRunFilesOnDisk(ctx, jr, jr.imageScan(), &report, moves, existingImages, onDisk.files, data.Full, progress)
 				continue
 			}
 
-			newMediaModel := model.Media{
-				LibraryPathID: libPath.ID,
-				Title:         v.Name,
-				Size:          v.Size,
-				Path:          v.Path,
-				MediaType:     model.MediaTypeEnum_Primary,
+			if err := handleFilesOnDisk(ctx, jr, jr.imageScan(), *job, *libPath, moves, existingImages, onDisk.files, data.Full, progress); err != nil {
+				accErrs = append(accErrs, errs.BuildError(err, "could not scan images of %v", libPath.Path))
 			}
-
-			createdMedia, err := jr.repo.Media().Create([]model.Media{newMediaModel})
-			if err != nil {
-				accErrs = append(accErrs, errs.BuildError(err, "could not create media"))
+		case onDisk := <-videoChan:
+			if onDisk.err != nil {
+				accErrs = append(accErrs, onDisk.err)
 				continue
 			}
-			if len(createdMedia) != 1 {
-				accErrs = append(accErrs, fmt.Errorf("expected a created media but there was none"))
+
+			addIgnored(ignored, onDisk.ignored)
+			existingVideos := filterMediaByExtensions(existingMedia, settings.videoExtensions)
+			if data.DryRun {
+				dryRunFilesOnDisk(ctx, jr, jr.videoScan(ctx), &report, moves, existingVideos, onDisk.files, data.Full, progress)
 				continue
 			}
 
-			width, height, err := ffmpeg.GetDimensions(data.Streams)
-			if err != nil {
-				jr.logger.Warningf("could not extract dimensions for %v. Setting to 0. Reason: %v", v.Path, err)
+			if err := handleFilesOnDisk(ctx, jr, jr.videoScan(ctx), *job, *libPath, moves, existingVideos, onDisk.files, data.Full, progress); err != nil {
+				accErrs = append(accErrs, errs.BuildError(err, "could not scan videos of %v", libPath.Path))
 			}
+		}
+	}
 
-			runtime, err := strconv.ParseFloat(data.Format.Duration, 32)
-			if err != nil {
-				jr.logger.Warningf("could not convert duration from string (%v) to float for video %v. Setting runtime to 0. Reason: %v", data.Format.Duration, v.Path, err)
-			}
+	// the number of files that each ignore file excluded is only kept in the outcome of a dry run,
+	// other scans write it to the job log
+	for _, source := range slices.Sorted(maps.Keys(ignored)) {
+		report.Ignored = append(report.Ignored, dto.ScanPathReportIgnore{Path: source, Files: ignored[source]})
+		if !data.DryRun {
+			jr.log(ctx).Infof("%v ignored %v files", source, ignored[source])
+		}
+	}
 
-			mediaId := createdMedia[0].ID
+	if data.DryRun {
+		if ctx.Err() != nil {
+			return fmt.Errorf("dry run ended due to cancellation or shutdown")
+		}
 
-			newVideoModel := model.Video{
-				MediaID: mediaId,
-				Height:  int32(height),
-				Width:   int32(width),
-				Runtime: float64(runtime),
-			}
+		outcome, err := json.Marshal(report)
+		if err != nil {
+			return errs.BuildError(err, "could not marshal scan path report")
+		}
 
-			createdVideos, err := jr.repo.Video().Insert([]model.Video{newVideoModel})
-			if err != nil {
-				accErrs = append(accErrs, errs.BuildError(err, "could not create video"))
-			}
+		o := string(outcome)
+		job.Outcome = &o
+		jr.log(ctx).Infof("Dry run of %v found %v new, %v changed, %v moved, %v missing and %v unprobeable files and %v ignore files", libPath.Path, len(report.New), len(report.Changed), len(report.Moved), len(report.Missing), len(report.ProbeFailures), len(report.Ignored))
+	}
+
+	return errors.Join(accErrs...)
+}
 
-			dto := (&dto.MediaOverviewDTO{}).FromModel(models.MediaOverviewModel{
-				Media: createdMedia[0],
-			})
-			jr.ws.MediaCreate(*dto)
+// pathsHash identifies a set of paths regardless of their order
+func pathsHash(paths []string) string {
+	sorted := slices.Clone(paths)
+	slices.Sort(sorted)
 
-			checksumJob, err := CreateGenerateChecksumJob(mediaId, job.ID)
-			if err != nil {
-				accErrs = append(accErrs, errs.BuildError(err, "could not create checksum job for media %v in job %v", mediaId, job.ID))
-			}
+	hash := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(hash[:8])
+}
+
+func addIgnored(ignored, add map[string]int) {
+	for source, count := range add {
+		ignored[source] += count
+	}
+}
+
+// scanPlan is the work a scan has to do for the files of one kind of media
+type scanPlan struct {
+	// new are the files that do not have media yet
+	new []media.File
+	// changed are the files that have media but have to be probed again
+	changed []media.File
+	// changedMedia is the media of the changed files by their path
+	changedMedia map[string]model.Media
+	// unmodified is unchanged media of which the modification time of the file was not known yet
+	unmodified []model.Media
+	// missing is the media of which the file was not found
+	missing []model.Media
+}
+
+// planScan compares the files on disk with the existing media. Files are only probed again when their size or
+// modification time changed, unless full is set in which case every file is probed again
+func planScan(existingMedia []model.Media, files []media.File, full bool) scanPlan {
+	existing := make(map[string]model.Media, len(existingMedia))
+	for _, m := range existingMedia {
+		existing[m.Path] = m
+	}
 
-			maxDimension := 400
-			if width > maxDimension {
-				height = ffmpeg.ScaleHeightByWidth(height, width, maxDimension)
-				width = maxDimension
+	plan := scanPlan{
+		new:          []media.File{},
+		changed:      []media.File{},
+		changedMedia: map[string]model.Media{},
+		unmodified:   []model.Media{},
+		missing:      media.FindNonExistentMedia(existingMedia, files),
+	}
+
+	for _, f := range files {
+		m, ok := existing[f.Path]
+		switch {
+		case !ok:
+			plan.new = append(plan.new, f)
+		case full || fileChanged(m, f):
+			plan.changed = append(plan.changed, f)
+			plan.changedMedia[f.Path] = m
+		case m.FileModified == nil:
+			m.FileModified = &f.Modified
+			plan.unmodified = append(plan.unmodified, m)
+		}
+	}
+
+	return plan
+}
+
+// fileChanged checks if the file is different from when its media was last scanned
+func fileChanged(m model.Media, f media.File) bool {
+	return m.Size != f.Size || (m.FileModified != nil && !m.FileModified.Equal(f.Modified))
+}
+
+// matchMoved matches the new files against missing media. The files that did not move still have to be probed and are
+// returned along with the checksums that were calculated while matching them
+func matchMoved(
+	moves *movedMedia,
+	files []media.File,
+	onMove func(moved model.Media, file media.File) error,
+	onError func(file media.File, err error),
+) ([]media.File, map[string]fileChecksums) {
+	toProbe := []media.File{}
+	checksums := map[string]fileChecksums{}
+	for _, f := range files {
+		moved, checksum, err := moves.match(f)
+		if err != nil {
+			onError(f, err)
+			continue
+		}
+
+		if moved != nil {
+			if err := onMove(*moved, f); err != nil {
+				onError(f, err)
 			}
+			continue
+		}
 
-			if height > maxDimension {
-				width = ffmpeg.ScaleWidthByHeight(height, width, maxDimension)
-				height = maxDimension
+		checksums[f.Path] = checksum
+		toProbe = append(toProbe, f)
+	}
+
+	return toProbe, checksums
+}
+
+// scanKind is how a scan probes the files of one kind of media and creates or updates their media
+type scanKind[T any] struct {
+	probe func(file media.File) (T, error)
+	// create creates the media of a new file
+	create func(ctx context.Context, job model.Job, libPath model.LibraryPath, file media.File, data T, checksums fileChecksums) error
+	// update updates the media of a file that changed
+	update func(ctx context.Context, job model.Job, m model.Media, file media.File, data T) error
+	// reportFile is how the file shows up in the report of a dry run
+	reportFile func(file media.File, data T) dto.ScanPathReportFile
+}
+
+func (jr *JobRunner) videoScan(ctx context.Context) scanKind[videoProbe] {
+	return scanKind[videoProbe]{
+		probe:  probeVideo(jr.log(ctx)),
+		create: jr.createVideo,
+		update: jr.updateVideo,
+		reportFile: func(file media.File, data videoProbe) dto.ScanPathReportFile {
+			return dto.ScanPathReportFile{
+				Path:    file.Path,
+				Size:    file.Size,
+				Width:   data.width,
+				Height:  data.height,
+				Runtime: data.runtime,
 			}
+		},
+	}
+}
 
-			relationType := model.MediaRelationTypeEnum_Thumbnail
-
-			assetPath := filepath.Join(
-				jr.env.Assets,
-				mediaId.String(),
-				fmt.Sprintf(
-					`%v.%v.%vx%v.webp`,
-					v.FileName,
-					relationType.String(),
-					height,
-					width,
-				))
-			thumbnailJob, err := CreateGenerateThumbnailJob(createdVideos[0], &job.ID, assetPath, 0, height, width, &relationType, nil)
-			if err != nil {
-				return errs.BuildError(err, "could not create generate thumbnail job")
+func (jr *JobRunner) imageScan() scanKind[imageProbe] {
+	return scanKind[imageProbe]{
+		probe:  probeImage,
+		create: jr.createImage,
+		update: jr.updateImage,
+		reportFile: func(file media.File, data imageProbe) dto.ScanPathReportFile {
+			return dto.ScanPathReportFile{
+				Path:   file.Path,
+				Size:   file.Size,
+				Width:  data.width,
+				Height: data.height,
 			}
+		},
+	}
+}
 
-			jobs := []model.Job{*checksumJob, *thumbnailJob}
+// dryRunFilesOnDisk adds what handleFilesOnDisk would do to the report without writing anything
+func dryRunFilesOnDisk[T any](ctx context.Context, jr *JobRunner, kind scanKind[T], report *dto.ScanPathReport, moves *movedMedia, existingMedia []model.Media, filesOnDisk []media.File, full bool, progress *jobProgress) {
+	plan := planScan(existingMedia, filesOnDisk, full)
+	moves.add(plan.missing)
+
+	toProbe, _ := matchMoved(moves, plan.new,
+		func(moved model.Media, file media.File) error {
+			report.Moved = append(report.Moved, dto.ScanPathReportMove{Id: moved.ID, From: moved.Path, To: file.Path})
+			return nil
+		},
+		func(file media.File, err error) {
+			report.ProbeFailures = append(report.ProbeFailures, dto.ScanPathReportFailure{Path: file.Path, Error: err.Error()})
+		})
+	toProbe = append(toProbe, plan.changed...)
+
+	progress.AddTotal(len(toProbe))
+
+	for p := range probeFiles(ctx, toProbe, jr.scanWorkers(), kind.probe) {
+		progress.Increment(p.file.Path)
+		if p.err != nil {
+			report.ProbeFailures = append(report.ProbeFailures, dto.ScanPathReportFailure{Path: p.file.Path, Error: p.err.Error()})
+			continue
+		}
 
-			jobs, err = jr.repo.Job().CreateAll(jobs)
-			if err != nil {
-				accErrs = append(accErrs, errs.BuildError(err, "could not create checksum and thumbnail job for video: %v", createdVideos[0].ID))
-			}
+		reportFile := kind.reportFile(p.file, p.data)
+		if _, ok := plan.changedMedia[p.file.Path]; ok {
+			report.Changed = append(report.Changed, reportFile)
+		} else {
+			report.New = append(report.New, reportFile)
 		}
+	}
+
+	for _, m := range moves.remaining(plan.missing) {
+		report.Missing = append(report.Missing, dto.ScanPathReportMedia{Id: m.ID, Path: m.Path})
+	}
+}
+
+// handleFilesOnDisk creates media for new files, updates the media of changed and moved files and marks the media of
+// files that are gone as missing. Files that could not be scanned are written to the job log so that a broken file does
+// not fail the whole scan. An error is only returned when the scan was stopped
+func handleFilesOnDisk[T any](ctx context.Context, jr *JobRunner, kind scanKind[T], job model.Job, libPath model.LibraryPath, moves *movedMedia, existingMedia []model.Media, filesOnDisk []media.File, full bool, progress *jobProgress) error {
+	plan := planScan(existingMedia, filesOnDisk, full)
+	moves.add(plan.missing)
+	jr.updateFileModified(ctx, plan.unmodified)
+
+	failed := 0
+	onError := func(file media.File, err error) {
+		failed++
+		jr.log(ctx).Errorf("could not scan %v: %v", file.Path, err.Error())
+	}
+
+	toProbe, checksums := matchMoved(moves, plan.new,
+		func(moved model.Media, file media.File) error {
+			return jr.moveMedia(ctx, moved, libPath, file)
+		},
+		onError)
+	toProbe = append(toProbe, plan.changed...)
+
+	progress.AddTotal(len(toProbe))
 
+	probeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for p := range probeFiles(probeCtx, toProbe, jr.scanWorkers(), kind.probe) {
+		progress.Increment(p.file.Path)
+		if p.err != nil {
+			onError(p.file, errs.BuildError(p.err, "could not probe"))
+			continue
+		}
+
+		var err error
+		if m, ok := plan.changedMedia[p.file.Path]; ok {
+			err = kind.update(ctx, job, m, p.file, p.data)
+		} else {
+			err = kind.create(ctx, job, libPath, p.file, p.data, checksums[p.file.Path])
+		}
+		if err != nil {
+			onError(p.file, err)
+		}
 	}
 
-	if len(accErrs) > 0 {
-		jr.logger.Errorf("ERRORS IN CREATION: %v", errors.Join(accErrs...).Error())
-		return errors.Join(accErrs...)
+	if ctx.Err() != nil {
+		return fmt.Errorf("partially done, ended due to cancellation or shutdown")
+	}
+
+	if remaining := moves.remaining(plan.missing); len(remaining) > 0 {
+		jr.removeMedia(ctx, remaining)
+	}
+
+	if failed > 0 {
+		jr.log(ctx).Warningf("%v of %v files could not be scanned", failed, len(plan.new)+len(plan.changed))
 	}
 
 	return nil
 }
 
-func (jr *JobRunner) removeMedia(nonExistentMedia []model.Media) {
+func (jr *JobRunner) createImage(ctx context.Context, job model.Job, libPath model.LibraryPath, i media.File, probe imageProbe, checksums fileChecksums) error {
+	newMediaModel := model.Media{
+		LibraryPathID: libPath.ID,
+		Title:         i.Name,
+		Size:          i.Size,
+		Path:          i.Path,
+		MediaType:     model.MediaTypeEnum_Primary,
+		FileModified:  &i.Modified,
+	}
+	checksums.apply(&newMediaModel)
+
+	createdMedia, err := jr.repo.Media().Create([]model.Media{newMediaModel})
+	if err != nil {
+		return errs.BuildError(err, "could not create media")
+	}
+	if len(createdMedia) != 1 {
+		return fmt.Errorf("expected a created media but there was none")
+	}
+
+	mediaId := createdMedia[0].ID
+
+	accErrs := []error{}
+	if _, err := jr.repo.Image().Create(&model.Image{
+		MediaID: mediaId,
+		Height:  int32(probe.height),
+		Width:   int32(probe.width),
+	}); err != nil {
+		accErrs = append(accErrs, errs.BuildError(err, "could not create image"))
+	}
+
+	dto := (&dto.MediaOverviewDTO{}).FromModel(models.MediaOverviewModel{
+		Media: createdMedia[0],
+	})
+	jr.ws.MediaCreate(*dto)
+
+	if err := jr.createChecksumJobs(ctx, job, mediaId, checksums); err != nil {
+		accErrs = append(accErrs, err)
+	}
+
+	return errors.Join(accErrs...)
+}
+
+// updateImage updates the media and image of a file that changed
+func (jr *JobRunner) updateImage(ctx context.Context, job model.Job, m model.Media, i media.File, probe imageProbe) error {
+	if err := jr.updateChangedMedia(ctx, job, m, i); err != nil {
+		return err
+	}
+
+	image := model.Image{
+		MediaID: m.ID,
+		Height:  int32(probe.height),
+		Width:   int32(probe.width),
+	}
+	if err := jr.repo.Image().UpdateByMediaId(image, postgres.ColumnList{table.Image.Height, table.Image.Width}); err != nil {
+		return errs.BuildError(err, "could not update image of changed file: %v", i.Path)
+	}
+
+	return nil
+}
+
+func (jr *JobRunner) createVideo(ctx context.Context, job model.Job, libPath model.LibraryPath, v media.File, probe videoProbe, checksums fileChecksums) error {
+	newMediaModel := model.Media{
+		LibraryPathID: libPath.ID,
+		Title:         v.Name,
+		Size:          v.Size,
+		Path:          v.Path,
+		MediaType:     model.MediaTypeEnum_Primary,
+		FileModified:  &v.Modified,
+	}
+	checksums.apply(&newMediaModel)
+
+	createdMedia, err := jr.repo.Media().Create([]model.Media{newMediaModel})
+	if err != nil {
+		return errs.BuildError(err, "could not create media")
+	}
+	if len(createdMedia) != 1 {
+		return fmt.Errorf("expected a created media but there was none")
+	}
+
+	mediaId := createdMedia[0].ID
+
+	newVideoModel := model.Video{
+		MediaID: mediaId,
+		Height:  int32(probe.height),
+		Width:   int32(probe.width),
+		Runtime: probe.runtime,
+	}
+
+	createdVideos, err := jr.repo.Video().Insert([]model.Video{newVideoModel})
+	if err != nil {
+		return errs.BuildError(err, "could not create video")
+	}
+
+	dto := (&dto.MediaOverviewDTO{}).FromModel(models.MediaOverviewModel{
+		Media: createdMedia[0],
+	})
+	jr.ws.MediaCreate(*dto)
+
+	width, height := probe.width, probe.height
+	maxDimension := 400
+	if width > maxDimension {
+		height = ffmpeg.ScaleHeightByWidth(height, width, maxDimension)
+		width = maxDimension
+	}
+
+	if height > maxDimension {
+		width = ffmpeg.ScaleWidthByHeight(height, width, maxDimension)
+		height = maxDimension
+	}
+
+	relationType := model.MediaRelationTypeEnum_Thumbnail
+
+	assetPath := filepath.Join(
+		jr.env.Assets,
+		mediaId.String(),
+		fmt.Sprintf(
+			`%v.%v.%vx%v.webp`,
+			v.FileName,
+			relationType.String(),
+			height,
+			width,
+		))
+	thumbnailJob, err := CreateGenerateThumbnailJob(createdVideos[0], &job.ID, assetPath, 0, height, width, &relationType, nil)
+	if err != nil {
+		return errs.BuildError(err, "could not create generate thumbnail job")
+	}
+
+	checksumJobs, err := jr.checksumJobs(mediaId, job.ID, checksums)
+	if err != nil {
+		return errs.BuildError(err, "could not create checksum jobs for media %v in job %v", mediaId, job.ID)
+	}
+	jobs := append([]model.Job{*thumbnailJob}, checksumJobs...)
+
+	_, skipped, err := jr.repo.Job().CreateAll(jobs)
+	if err != nil {
+		return errs.BuildError(err, "could not create checksum and thumbnail job for video: %v", createdVideos[0].ID)
+	}
+
+	jr.logSkippedJobs(ctx, skipped)
+
+	return nil
+}
+
+// updateVideo updates the media and video of a file that changed
+func (jr *JobRunner) updateVideo(ctx context.Context, job model.Job, m model.Media, v media.File, probe videoProbe) error {
+	if err := jr.updateChangedMedia(ctx, job, m, v); err != nil {
+		return err
+	}
+
+	video := model.Video{
+		MediaID: m.ID,
+		Height:  int32(probe.height),
+		Width:   int32(probe.width),
+		Runtime: probe.runtime,
+	}
+	if err := jr.repo.Video().UpdateByMediaId(video, postgres.ColumnList{table.Video.Height, table.Video.Width, table.Video.Runtime}); err != nil {
+		return errs.BuildError(err, "could not update video of changed file: %v", v.Path)
+	}
+
+	return nil
+}
+
+// updateChangedMedia updates the size and modification time of media that was probed again.
+// The checksum is cleared and calculated again when the file changed
+func (jr *JobRunner) updateChangedMedia(ctx context.Context, job model.Job, m model.Media, f media.File) error {
+	changed := fileChanged(m, f)
+
+	m.Size = f.Size
+	m.FileModified = &f.Modified
+	columns := postgres.ColumnList{table.Media.Size, table.Media.FileModified}
+	if changed {
+		m.Checksum, m.ChecksumAlgorithm = nil, nil
+		m.PartialChecksum, m.PartialChecksumAlgorithm = nil, nil
+		columns = append(columns,
+			table.Media.Checksum,
+			table.Media.ChecksumAlgorithm,
+			table.Media.PartialChecksum,
+			table.Media.PartialChecksumAlgorithm,
+		)
+	}
+
+	if _, err := jr.repo.Media().Update(m, columns); err != nil {
+		return errs.BuildError(err, "could not update media of changed file: %v", f.Path)
+	}
+
+	jr.ws.MediaOverviewUpdate(*(&dto.MediaOverviewDTO{}).FromModel(models.MediaOverviewModel{Media: m}))
+
+	if !changed {
+		return nil
+	}
+
+	jr.log(ctx).Infof("File of media %v changed: %v", m.ID, f.Path)
+
+	return jr.createChecksumJobs(ctx, job, m.ID, fileChecksums{})
+}
+
+func (jr *JobRunner) createChecksumJobs(ctx context.Context, job model.Job, mediaId uuid.UUID, checksums fileChecksums) error {
+	checksumJobs, err := jr.checksumJobs(mediaId, job.ID, checksums)
+	if err != nil {
+		return errs.BuildError(err, "could not create checksum jobs for media %v in job %v", mediaId, job.ID)
+	}
+	if len(checksumJobs) == 0 {
+		return nil
+	}
+
+	_, skipped, err := jr.repo.Job().CreateAll(checksumJobs)
+	if err != nil {
+		return errs.BuildError(err, "could not create checksum job for media: %v", mediaId)
+	}
+
+	jr.logSkippedJobs(ctx, skipped)
+
+	return nil
+}
+
+// updateFileModified stores the modification time of files of media that was scanned before it was kept
+func (jr *JobRunner) updateFileModified(ctx context.Context, unmodified []model.Media) {
+	for _, m := range unmodified {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if _, err := jr.repo.Media().Update(m, postgres.ColumnList{table.Media.FileModified}); err != nil {
+			jr.log(ctx).Warningf("could not store the modification time of %v: %v", m.Path, err.Error())
+		}
+	}
+}
+
+func (jr *JobRunner) removeMedia(ctx context.Context, nonExistentMedia []model.Media) {
 	for _, v := range nonExistentMedia {
 		select {
-		case <-jr.shutdownCtx.Done():
+		case <-ctx.Done():
 			return
 		default:
 			v.Exists = false
 			err := jr.repo.Media().UpdateExists(v)
 			if err != nil {
-				jr.logger.Errorf("Error occured while updating the existance state of the media '%v': %v", v.ID, err)
+				jr.log(ctx).Errorf("Error occured while updating the existance state of the media '%v': %v", v.ID, err)
 			}
 
 			jr.ws.MediaDelete(dto.MediaOverviewDTO{Id: v.ID, Deleted: true})
@@ -223,8 +769,31 @@ func (jr *JobRunner) removeMedia(nonExistentMedia []model.Media) {
 	}
 }
 
-func mediaExists(existingMedia []model.Media, path string) bool {
-	return slices.ContainsFunc(existingMedia, func(existingMedia model.Media) bool {
-		return existingMedia.Path == path
-	})
+// filterMediaByExtensions returns the media of which the file has one of the extensions.
+// Videos and images are scanned separately so media of the other kind should not be marked as missing
+func filterMediaByExtensions(existingMedia []model.Media, extensions []string) []model.Media {
+	filtered := []model.Media{}
+	for _, m := range existingMedia {
+		if hasExtension(m.Path, extensions) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
+
+func hasExtension(path string, extensions []string) bool {
+	return slices.Contains(extensions, filepath.Ext(path))
+}
+
+// filterMediaInPaths returns the media of which the file is one of the paths or in one of them
+func filterMediaInPaths(existingMedia []model.Media, paths []string) []model.Media {
+	filtered := []model.Media{}
+	for _, m := range existingMedia {
+		if slices.ContainsFunc(paths, func(p string) bool { return media.IsInPath(p, m.Path) }) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
 }
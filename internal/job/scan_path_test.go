@@ -0,0 +1,202 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CreateScanPathJob(t *testing.T) {
+	libraryPathId, _ := uuid.NewRandom()
+	jobId, _ := uuid.NewRandom()
+
+	actual, err := CreateScanPathJob(libraryPathId, &jobId, dto.JobPriority_High)
+	assert.Nil(t, err)
+
+	actualData := *actual.Data
+	actual.Data = nil
+
+	expectedData := fmt.Sprintf(`{"libraryPathId":"%v"}`, libraryPathId)
+	expectedDedupKey := fmt.Sprintf("scan_path:%v", libraryPathId)
+	expected := model.Job{
+		JobType:  model.JobTypeEnum_ScanPath,
+		Status:   model.JobStatusEnum_NotStarted,
+		Data:     nil,
+		Parent:   &jobId,
+		Priority: dto.JobPriority_High,
+		DedupKey: &expectedDedupKey,
+	}
+
+	assert.Equal(t, expected, *actual)
+	assert.Equal(t, expectedData, actualData)
+}
+
+func Test_FilterMediaInPaths_ShouldOnlyReturnMediaInThePaths(t *testing.T) {
+	inDir := model.Media{Path: "/library/dir/video.mp4"}
+	file := model.Media{Path: "/library/file.mp4"}
+	similarDir := model.Media{Path: "/library/dir2/video.mp4"}
+	other := model.Media{Path: "/library/other.mp4"}
+
+	actual := filterMediaInPaths([]model.Media{inDir, file, similarDir, other}, []string{"/library/dir", "/library/file.mp4"})
+
+	assert.Equal(t, []model.Media{inDir, file}, actual)
+}
+
+func Test_PlanScan_ShouldOnlyProbeNewAndChangedFiles(t *testing.T) {
+	modified := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	later := modified.Add(time.Minute)
+
+	unchanged := model.Media{ID: uuid.New(), Path: "/library/unchanged.mp4", Size: 10, FileModified: &modified}
+	resized := model.Media{ID: uuid.New(), Path: "/library/resized.mp4", Size: 10, FileModified: &modified}
+	touched := model.Media{ID: uuid.New(), Path: "/library/touched.mp4", Size: 10, FileModified: &modified}
+	unknown := model.Media{ID: uuid.New(), Path: "/library/unknown.mp4", Size: 10}
+	missing := model.Media{ID: uuid.New(), Path: "/library/missing.mp4", Size: 10, FileModified: &modified}
+
+	newFile := media.File{Path: "/library/new.mp4", Size: 10, Modified: modified}
+	resizedFile := media.File{Path: resized.Path, Size: 20, Modified: modified}
+	touchedFile := media.File{Path: touched.Path, Size: 10, Modified: later}
+	files := []media.File{
+		{Path: unchanged.Path, Size: 10, Modified: modified},
+		resizedFile,
+		touchedFile,
+		{Path: unknown.Path, Size: 10, Modified: modified},
+		newFile,
+	}
+
+	plan := planScan([]model.Media{unchanged, resized, touched, unknown, missing}, files, false)
+
+	assert.Equal(t, []media.File{newFile}, plan.new)
+	assert.Equal(t, []media.File{resizedFile, touchedFile}, plan.changed)
+	assert.Equal(t, map[string]model.Media{resized.Path: resized, touched.Path: touched}, plan.changedMedia)
+	assert.Len(t, plan.unmodified, 1)
+	assert.Equal(t, unknown.ID, plan.unmodified[0].ID)
+	assert.Equal(t, modified, *plan.unmodified[0].FileModified)
+	assert.Equal(t, []model.Media{missing}, plan.missing)
+}
+
+func Test_PlanScan_WithFull_ShouldProbeAllExistingFiles(t *testing.T) {
+	modified := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	unchanged := model.Media{ID: uuid.New(), Path: "/library/unchanged.mp4", Size: 10, FileModified: &modified}
+	file := media.File{Path: unchanged.Path, Size: 10, Modified: modified}
+
+	plan := planScan([]model.Media{unchanged}, []media.File{file}, true)
+
+	assert.Empty(t, plan.new)
+	assert.Equal(t, []media.File{file}, plan.changed)
+	assert.Empty(t, plan.unmodified)
+}
+
+func Test_NewScanSettings_WithoutLibrarySettings_ShouldUseDefaults(t *testing.T) {
+	settings, err := newScanSettings(model.Library{}, model.LibraryPath{Path: "/library"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, videoExtensions[:], settings.videoExtensions)
+	assert.Equal(t, imageExtensions[:], settings.imageExtensions)
+	assert.False(t, settings.exclude.Excludes("/library/@eaDir/video.mp4", false))
+}
+
+func Test_NewScanSettings_WithLibrarySettings_ShouldOverrideDefaults(t *testing.T) {
+	scanSettings := `{"videoExtensions":[".mkv"],"imageExtensions":[],"exclude":["@eaDir/"]}`
+	library := model.Library{ScanSettings: &scanSettings}
+
+	settings, err := newScanSettings(library, model.LibraryPath{Path: "/library"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{".mkv"}, settings.videoExtensions)
+	assert.Empty(t, settings.imageExtensions)
+	assert.True(t, settings.exclude.Excludes("/library/@eaDir/video.mkv", false))
+}
+
+func Test_NewScanSettings_WithLibrarySettingsThatCanNotBeParsed_ShouldReturnError(t *testing.T) {
+	scanSettings := `{"videoExtensions":".mkv"}`
+	library := model.Library{ScanSettings: &scanSettings}
+
+	settings, err := newScanSettings(library, model.LibraryPath{Path: "/library"})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, settings)
+}
+
+func Test_ScanRoots_WithIgnoreFile_ShouldScanItsDirectory(t *testing.T) {
+	libPath := model.LibraryPath{Path: "/library"}
+	data := dto.ScanPathData{Paths: []string{"/library/movies/video.mp4", "/library/movies/" + media.IgnoreFileName, "/library/other.mp4"}}
+
+	roots, _ := scanRoots(libPath, data)
+
+	assert.Equal(t, []string{"/library/movies", "/library/other.mp4"}, roots)
+}
+
+func Test_GetFilesByExtension_WithRootThatCanNotBeWalked_ShouldSendTheError(t *testing.T) {
+	jr := &JobRunner{
+		logger: logger.New(&environment.EnvironmentVariables{LogLevel: "none"}),
+		wg:     &sync.WaitGroup{},
+	}
+	root := filepath.Join(t.TempDir(), "removed")
+	exclude, _ := media.NewExclude(root, nil)
+
+	ch := make(chan filesOnDisk, 1)
+	jr.wg.Add(1)
+	jr.getFilesByExtension(context.Background(), []string{root}, videoExtensions[:], exclude, ch)
+
+	onDisk := <-ch
+	assert.NotNil(t, onDisk.err)
+	assert.Empty(t, onDisk.files)
+}
+
+func Test_ScanPathDedupKey_DryRun_ShouldNotMatchScanPath(t *testing.T) {
+	id, _ := uuid.NewRandom()
+
+	scanKey := scanPathJob.DedupKey(dto.ScanPathData{LibraryPathId: id})
+	dryRunKey := scanPathJob.DedupKey(dto.ScanPathData{LibraryPathId: id, DryRun: true})
+
+	assert.NotEqual(t, *scanKey, *dryRunKey)
+}
+
+func Test_ScanPathDedupKey_WithPaths_ShouldIgnoreTheOrderOfPaths(t *testing.T) {
+	id, _ := uuid.NewRandom()
+
+	scanKey := scanPathJob.DedupKey(dto.ScanPathData{LibraryPathId: id})
+	pathsKey := scanPathJob.DedupKey(dto.ScanPathData{LibraryPathId: id, Paths: []string{"/a", "/b"}})
+	reorderedKey := scanPathJob.DedupKey(dto.ScanPathData{LibraryPathId: id, Paths: []string{"/b", "/a"}})
+
+	assert.NotEqual(t, *scanKey, *pathsKey)
+	assert.Equal(t, *pathsKey, *reorderedKey)
+}
+
+func Test_HandleFilesOnDisk_WithFilesThatCanNotBeProbed_ShouldScanTheOtherFiles(t *testing.T) {
+	m := setupProcessJob(t)
+	m.jobRepo.EXPECT().UpdateProgress(gomock.Any()).Return(nil).AnyTimes()
+
+	created := []string{}
+	kind := scanKind[imageProbe]{
+		probe: func(file media.File) (imageProbe, error) {
+			if file.Path == "/library/broken.jpg" {
+				return imageProbe{}, fmt.Errorf("not an image")
+			}
+			return imageProbe{width: 1, height: 1}, nil
+		},
+		create: func(ctx context.Context, job model.Job, libPath model.LibraryPath, file media.File, data imageProbe, checksums fileChecksums) error {
+			created = append(created, file.Path)
+			return nil
+		},
+	}
+	files := []media.File{{Path: "/library/broken.jpg"}, {Path: "/library/image.jpg"}}
+	job := &model.Job{ID: uuid.New()}
+
+	err := handleFilesOnDisk(context.Background(), m.jr, kind, *job, model.LibraryPath{}, newMovedMedia(nil), []model.Media{}, files, false, m.jr.newProgress(job))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/library/image.jpg"}, created)
+}
@@ -0,0 +1,65 @@
+package job
+
+import (
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/media"
+)
+
+// scanSettings are the files that a scan of a library path picks up
+type scanSettings struct {
+	videoExtensions []string
+	imageExtensions []string
+	exclude         *media.Exclude
+}
+
+// newScanSettings uses the scan settings of the library and falls back to the default extensions
+// when the library does not set them
+func newScanSettings(library model.Library, libPath model.LibraryPath) (*scanSettings, error) {
+	settings, librarySettings, err := newLibraryScanSettings(library)
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []string
+	if librarySettings != nil {
+		patterns = librarySettings.Exclude
+	}
+
+	// the exclude is rooted at the library path even without patterns so that walks of folders in it pick up
+	// the ignore files of the folders above them
+	exclude, err := media.NewExclude(libPath.Path, patterns)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not parse exclude patterns of library %v", library.ID)
+	}
+	settings.exclude = exclude
+
+	return settings, nil
+}
+
+// newLibraryScanSettings are the extensions that scans of the paths of the library pick up. The exclude is left out
+// as it is rooted at a library path. The scan settings of the library are nil when it does not set them
+func newLibraryScanSettings(library model.Library) (*scanSettings, *dto.LibraryScanSettingsDTO, error) {
+	settings := &scanSettings{
+		videoExtensions: videoExtensions[:],
+		imageExtensions: imageExtensions[:],
+	}
+
+	librarySettings, err := (&dto.LibraryScanSettingsDTO{}).FromModel(library)
+	if err != nil {
+		return nil, nil, err
+	}
+	if librarySettings == nil {
+		return settings, nil, nil
+	}
+
+	if librarySettings.VideoExtensions != nil {
+		settings.videoExtensions = librarySettings.VideoExtensions
+	}
+	if librarySettings.ImageExtensions != nil {
+		settings.imageExtensions = librarySettings.ImageExtensions
+	}
+
+	return settings, librarySettings, nil
+}
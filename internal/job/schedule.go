@@ -0,0 +1,37 @@
+package job
+
+import "time"
+
+const schedulePollInterval = 30 * time.Second
+
+// scheduler periodically creates jobs for job schedules that are due.
+// Created jobs wake the job runners through the job created notification
+func (jr *JobRunner) scheduler() {
+	defer jr.wg.Done()
+
+	ticker := time.NewTicker(schedulePollInterval)
+	defer ticker.Stop()
+
+	jr.runDueSchedules()
+	for {
+		select {
+		case <-jr.shutdownCtx.Done():
+			jr.logger.Debug("Shutdown signal received. Stopping scheduler")
+			return
+		case <-ticker.C:
+			jr.runDueSchedules()
+		}
+	}
+}
+
+func (jr *JobRunner) runDueSchedules() {
+	jobs, err := jr.service.JobSchedule().RunDue(time.Now())
+	if err != nil {
+		jr.logger.Errorf("could not run due job schedules: %v", err.Error())
+		return
+	}
+
+	for _, j := range jobs {
+		jr.ws.JobCreate(j)
+	}
+}
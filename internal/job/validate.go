@@ -0,0 +1,50 @@
+package job
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/models"
+	"github.com/slugger7/exorcist/internal/repository"
+)
+
+func validateLibrary(repo repository.Repository, id uuid.UUID) (*model.Library, error) {
+	library, err := repo.Library().GetById(id)
+	if err != nil {
+		return nil, errs.BuildError(err, "getting library by id: %v", id.String())
+	}
+
+	if library == nil {
+		return nil, fmt.Errorf("no library found with id: %v", id.String())
+	}
+
+	return library, nil
+}
+
+func validateLibraryPath(repo repository.Repository, id uuid.UUID) (*model.LibraryPath, error) {
+	libraryPath, err := repo.LibraryPath().GetById(id)
+	if err != nil {
+		return nil, errs.BuildError(err, "getting library path by id: %v", id.String())
+	}
+
+	if libraryPath == nil {
+		return nil, fmt.Errorf("no library path found with id: %v", id.String())
+	}
+
+	return libraryPath, nil
+}
+
+func validateMedia(repo repository.Repository, id uuid.UUID) (*models.Media, error) {
+	media, err := repo.Media().GetById(id)
+	if err != nil {
+		return nil, errs.BuildError(err, "getting media by id: %v", id.String())
+	}
+
+	if media == nil {
+		return nil, fmt.Errorf("no media found with id: %v", id.String())
+	}
+
+	return media, nil
+}
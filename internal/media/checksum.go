@@ -0,0 +1,103 @@
+package media
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/zeebo/xxh3"
+)
+
+// PartialChecksumChunk is the number of bytes at the start and at the end of a file that a partial checksum is
+// calculated from. Changing it makes existing partial checksums incomparable to new ones
+const PartialChecksumChunk = 4 << 20
+
+// Checksum is the hex encoded hash of a file and the algorithm that calculated it.
+// The digests of the algorithms have different lengths so checksums of different algorithms never match
+type Checksum struct {
+	Value     string
+	Algorithm model.ChecksumAlgorithmEnum
+}
+
+func newHash(algorithm model.ChecksumAlgorithmEnum) (hash.Hash, error) {
+	switch algorithm {
+	case model.ChecksumAlgorithmEnum_Md5:
+		return md5.New(), nil
+	case model.ChecksumAlgorithmEnum_Sha256:
+		return sha256.New(), nil
+	case model.ChecksumAlgorithmEnum_Sha512:
+		return sha512.New(), nil
+	case model.ChecksumAlgorithmEnum_Xxh3:
+		// not a cryptographic hash but a lot faster on large files
+		return xxh3.New(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported checksum algorithm: %v", algorithm)
+}
+
+// CalculateChecksum hashes the whole file
+func CalculateChecksum(filePath string, algorithm model.ChecksumAlgorithmEnum) (*Checksum, error) {
+	hash, err := newHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errs.BuildError(err, "error opening file")
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, errs.BuildError(err, "error calculating %v hash", algorithm)
+	}
+
+	return &Checksum{Value: hex.EncodeToString(hash.Sum(nil)), Algorithm: algorithm}, nil
+}
+
+// CalculatePartialChecksum hashes the size of the file along with its first and last PartialChecksumChunk bytes.
+// It is cheap on large files and good enough to tell files apart but files that only differ in the middle have the
+// same partial checksum
+func CalculatePartialChecksum(filePath string, algorithm model.ChecksumAlgorithmEnum) (*Checksum, error) {
+	hash, err := newHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errs.BuildError(err, "error opening file")
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errs.BuildError(err, "error reading file info")
+	}
+	size := info.Size()
+
+	hash.Write(binary.BigEndian.AppendUint64(nil, uint64(size)))
+
+	if size <= 2*PartialChecksumChunk {
+		if _, err := io.Copy(hash, file); err != nil {
+			return nil, errs.BuildError(err, "error calculating partial %v hash", algorithm)
+		}
+	} else {
+		if _, err := io.CopyN(hash, file, PartialChecksumChunk); err != nil {
+			return nil, errs.BuildError(err, "error calculating partial %v hash of the start of the file", algorithm)
+		}
+		if _, err := io.Copy(hash, io.NewSectionReader(file, size-PartialChecksumChunk, PartialChecksumChunk)); err != nil {
+			return nil, errs.BuildError(err, "error calculating partial %v hash of the end of the file", algorithm)
+		}
+	}
+
+	return &Checksum{Value: hex.EncodeToString(hash.Sum(nil)), Algorithm: algorithm}, nil
+}
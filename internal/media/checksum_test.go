@@ -0,0 +1,82 @@
+package media_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	. "github.com/slugger7/exorcist/internal/media"
+)
+
+func Test_CalculateChecksum_ShouldRecordTheAlgorithm(t *testing.T) {
+	content := []byte("some video")
+	path := writeTestFile(t, filepath.Join(t.TempDir(), "video.mp4"), string(content))
+
+	actual, err := CalculateChecksum(path, model.ChecksumAlgorithmEnum_Sha256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := sha256.Sum256(content)
+	if actual.Value != hex.EncodeToString(expected[:]) || actual.Algorithm != model.ChecksumAlgorithmEnum_Sha256 {
+		t.Errorf("expected sha256 checksum %x but got %v", expected, actual)
+	}
+}
+
+func Test_CalculateChecksum_WithXxh3_ShouldHashTheFile(t *testing.T) {
+	path := writeTestFile(t, filepath.Join(t.TempDir(), "empty.mp4"), "")
+
+	actual, err := CalculateChecksum(path, model.ChecksumAlgorithmEnum_Xxh3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual.Value != "2d06800538d394c2" {
+		t.Errorf("expected the xxh3 checksum of an empty file but got %v", actual.Value)
+	}
+}
+
+func Test_CalculateChecksum_WithUnsupportedAlgorithm_ShouldReturnError(t *testing.T) {
+	path := writeTestFile(t, filepath.Join(t.TempDir(), "video.mp4"), "some video")
+
+	if _, err := CalculateChecksum(path, model.ChecksumAlgorithmEnum("crc32")); err == nil {
+		t.Error("expected an error for an unsupported algorithm")
+	}
+}
+
+func Test_CalculatePartialChecksum_ShouldOnlyHashTheStartAndEndOfLargeFiles(t *testing.T) {
+	content := make([]byte, 2*PartialChecksumChunk+10)
+	a := writeTestFile(t, filepath.Join(t.TempDir(), "a.mp4"), string(content))
+	content[PartialChecksumChunk+5] = 1
+	b := writeTestFile(t, filepath.Join(t.TempDir(), "b.mp4"), string(content))
+	content[len(content)-1] = 1
+	c := writeTestFile(t, filepath.Join(t.TempDir(), "c.mp4"), string(content))
+
+	checksumA, err := CalculatePartialChecksum(a, model.ChecksumAlgorithmEnum_Md5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksumB, _ := CalculatePartialChecksum(b, model.ChecksumAlgorithmEnum_Md5)
+	checksumC, _ := CalculatePartialChecksum(c, model.ChecksumAlgorithmEnum_Md5)
+
+	if checksumA.Value != checksumB.Value {
+		t.Errorf("expected files that differ in the middle to have the same partial checksum")
+	}
+	if checksumA.Value == checksumC.Value {
+		t.Errorf("expected files that differ at the end to have different partial checksums")
+	}
+}
+
+func Test_CalculatePartialChecksum_ShouldIncludeTheSize(t *testing.T) {
+	short := writeTestFile(t, filepath.Join(t.TempDir(), "short.mp4"), "\x00")
+	long := writeTestFile(t, filepath.Join(t.TempDir(), "long.mp4"), "\x00\x00")
+
+	checksumShort, _ := CalculatePartialChecksum(short, model.ChecksumAlgorithmEnum_Xxh3)
+	checksumLong, _ := CalculatePartialChecksum(long, model.ChecksumAlgorithmEnum_Xxh3)
+
+	if checksumShort.Value == checksumLong.Value {
+		t.Errorf("expected files of different sizes to have different partial checksums")
+	}
+}
@@ -0,0 +1,252 @@
+package media
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// IgnoreFileName is the name of the files with exclude patterns for the directory that they are in and its subdirectories
+const IgnoreFileName = ".exorcistignore"
+
+// Exclude matches paths in a root against gitignore style patterns.
+//
+// A pattern without a slash matches a file or directory with that name at any depth, a pattern with a slash is
+// relative to the root. A trailing slash only matches directories, * and ? do not match a slash, ** matches any
+// number of directories and a leading ! includes a path again that an earlier pattern excluded.
+// Blank lines and lines starting with # are ignored.
+//
+// Patterns of ignore files are relative to the directory of the ignore file and come after the patterns of the
+// directories above it
+type Exclude struct {
+	root  string
+	rules []excludeRule
+}
+
+type excludeRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+	// base is the directory relative to the root that the pattern is relative to
+	base string
+	// source is the ignore file that the pattern is from or empty when it was not from an ignore file
+	source string
+}
+
+// NewExclude parses the patterns for paths in the root
+func NewExclude(root string, patterns []string) (*Exclude, error) {
+	e := &Exclude{root: filepath.Clean(root), rules: []excludeRule{}}
+	for _, p := range patterns {
+		rule, ok, err := parseExcludePattern(p)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			e.rules = append(e.rules, rule)
+		}
+	}
+
+	return e, nil
+}
+
+// ValidateExcludePatterns returns an error for the first pattern that can not be parsed
+func ValidateExcludePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, _, err := parseExcludePattern(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseExcludePattern(pattern string) (excludeRule, bool, error) {
+	p := strings.TrimSpace(pattern)
+	if p == "" || strings.HasPrefix(p, "#") {
+		return excludeRule{}, false, nil
+	}
+
+	rule := excludeRule{}
+	if strings.HasPrefix(p, "!") {
+		rule.negate = true
+		p = p[1:]
+	}
+	if strings.HasSuffix(p, "/") {
+		rule.dirOnly = true
+		p = strings.TrimRight(p, "/")
+	}
+
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return excludeRule{}, false, fmt.Errorf("exclude pattern does not match anything: %v", pattern)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if strings.HasPrefix(p[i:], "**") {
+				switch {
+				case strings.HasPrefix(p[i:], "**/"):
+					expr.WriteString("(?:.*/)?")
+					i += 2
+				default:
+					expr.WriteString(".*")
+					i++
+				}
+				continue
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				return excludeRule{}, false, fmt.Errorf("unclosed character class in exclude pattern: %v", pattern)
+			}
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(string(p[i])))
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return excludeRule{}, false, fmt.Errorf("invalid exclude pattern %v: %w", pattern, err)
+	}
+	rule.pattern = re
+
+	return rule, true, nil
+}
+
+// Match checks if the file or directory is excluded by the patterns. Parent directories are not checked
+func (e *Exclude) Match(path string, isDir bool) bool {
+	excluded, _ := e.match(path, isDir)
+	return excluded
+}
+
+// match also returns the ignore file of the pattern that excluded the path
+func (e *Exclude) match(path string, isDir bool) (bool, string) {
+	if e == nil || len(e.rules) == 0 {
+		return false, ""
+	}
+
+	rel, err := filepath.Rel(e.root, path)
+	if err != nil || rel == "." || !IsInPath(e.root, path) {
+		return false, ""
+	}
+	rel = filepath.ToSlash(rel)
+
+	excluded, source := false, ""
+	for _, r := range e.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+
+		relToBase := rel
+		if r.base != "" {
+			if !strings.HasPrefix(rel, r.base+"/") {
+				continue
+			}
+			relToBase = rel[len(r.base)+1:]
+		}
+
+		if r.pattern.MatchString(relToBase) {
+			excluded, source = !r.negate, r.source
+		}
+	}
+
+	return excluded, source
+}
+
+// Excludes checks if the path or one of its parent directories in the root is excluded by the patterns
+func (e *Exclude) Excludes(path string, isDir bool) bool {
+	if e == nil || len(e.rules) == 0 {
+		return false
+	}
+
+	dir := filepath.Dir(path)
+	if dir != path && IsInPath(e.root, dir) && e.Excludes(dir, true) {
+		return true
+	}
+
+	return e.Match(path, isDir)
+}
+
+// forWalk returns a copy of the exclude that ignore files can be added to while walking the root.
+// The ignore files of the directories above the root are added to it.
+// Without an exclude only the ignore files in the root are picked up
+func (e *Exclude) forWalk(root string) *Exclude {
+	if e == nil {
+		return &Exclude{root: filepath.Clean(root), rules: []excludeRule{}}
+	}
+
+	walk := &Exclude{root: e.root, rules: slices.Clone(e.rules)}
+
+	dirs := []string{}
+	for dir := filepath.Dir(filepath.Clean(root)); IsInPath(e.root, dir); dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == e.root {
+			break
+		}
+	}
+
+	for _, dir := range slices.Backward(dirs) {
+		walk.addIgnoreFile(dir)
+	}
+
+	return walk
+}
+
+// addIgnoreFile adds the patterns of the ignore file in the directory when there is one.
+// Patterns that can not be parsed are skipped like git does
+func (e *Exclude) addIgnoreFile(dir string) {
+	source := filepath.Join(dir, IgnoreFileName)
+	f, err := os.Open(source)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	base, err := filepath.Rel(e.root, dir)
+	if err != nil {
+		return
+	}
+	base = filepath.ToSlash(base)
+	if base == "." {
+		base = ""
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		rule, ok, err := parseExcludePattern(scanner.Text())
+		if err != nil || !ok {
+			continue
+		}
+
+		rule.base = base
+		rule.source = source
+		e.rules = append(e.rules, rule)
+	}
+}
@@ -0,0 +1,76 @@
+package media_test
+
+import (
+	"testing"
+
+	. "github.com/slugger7/exorcist/internal/media"
+)
+
+func Test_Exclude_Match(t *testing.T) {
+	patterns := []string{
+		"# synology thumbnails",
+		"@eaDir/",
+		".Trash-*",
+		"/Samples",
+		"**/extras/*.mkv",
+		"*.part",
+		"!keep.part",
+	}
+	exclude, err := NewExclude("/library", patterns)
+	if err != nil {
+		t.Fatalf("could not create exclude: %v", err)
+	}
+
+	cases := []struct {
+		path     string
+		isDir    bool
+		excluded bool
+	}{
+		{"/library/@eaDir", true, true},
+		{"/library/movies/@eaDir", true, true},
+		{"/library/@eaDir", false, false},
+		{"/library/.Trash-1000", true, true},
+		{"/library/Samples", true, true},
+		{"/library/movies/Samples", true, false},
+		{"/library/movies/extras/trailer.mkv", false, true},
+		{"/library/extras/trailer.mkv", false, true},
+		{"/library/movies/extras/trailer.mp4", false, false},
+		{"/library/movies/video.part", false, true},
+		{"/library/movies/keep.part", false, false},
+		{"/library/movies/video.mp4", false, false},
+		{"/other/@eaDir", true, false},
+	}
+
+	for _, c := range cases {
+		if actual := exclude.Match(c.path, c.isDir); actual != c.excluded {
+			t.Errorf("expected %v (dir: %v) to be excluded: %v but was: %v", c.path, c.isDir, c.excluded, actual)
+		}
+	}
+}
+
+func Test_Exclude_Excludes_ShouldCheckParentDirectories(t *testing.T) {
+	exclude, err := NewExclude("/library", []string{"@eaDir/"})
+	if err != nil {
+		t.Fatalf("could not create exclude: %v", err)
+	}
+
+	if !exclude.Excludes("/library/movies/@eaDir/video.mp4", false) {
+		t.Errorf("expected a file in an excluded directory to be excluded")
+	}
+	if exclude.Excludes("/library/movies/video.mp4", false) {
+		t.Errorf("expected a file outside of excluded directories not to be excluded")
+	}
+}
+
+func Test_Exclude_WithNilExclude_ShouldNotExcludeAnything(t *testing.T) {
+	var exclude *Exclude
+	if exclude.Excludes("/library/video.mp4", false) {
+		t.Errorf("expected nil exclude not to exclude anything")
+	}
+}
+
+func Test_ValidateExcludePatterns_WithUnclosedCharacterClass_ShouldReturnError(t *testing.T) {
+	if err := ValidateExcludePatterns([]string{"*.mp4", "video[12.mp4"}); err == nil {
+		t.Errorf("expected an error for an unclosed character class")
+	}
+}
@@ -1,18 +1,15 @@
 package media
 
 import (
-	"crypto/md5"
-	"encoding/hex"
-	"io"
 	"io/fs"
 	"math"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
-	errs "github.com/slugger7/exorcist/internal/errors"
 )
 
 type File struct {
@@ -21,27 +18,22 @@ type File struct {
 	Path      string
 	Extension string
 	Size      int64
+	// Modified is in UTC and truncated to microseconds so that it can be compared after a round trip through the database
+	Modified time.Time
 }
 
-func CalculateMD5(filePath string) (string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", errs.BuildError(err, "error opening file")
-	}
-	defer file.Close()
-
-	hash := md5.New()
+func GetRelativePath(root, path string) string {
+	return strings.Replace(path, root, "", 1)
+}
 
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", errs.BuildError(err, "error calculating MD5 hash")
+// IsInPath checks if the path is the root or a path in the root
+func IsInPath(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
 	}
 
-	checksum := hex.EncodeToString(hash.Sum(nil))
-	return checksum, nil
-}
-
-func GetRelativePath(root, path string) string {
-	return strings.Replace(path, root, "", 1)
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
 }
 
 func GetTitleOfFile(filename string) string {
@@ -63,41 +55,92 @@ func GetFileSize(path string) (int64, error) {
 	return int64(math.Abs(float64(fileinfo.Size()))), nil
 }
 
-func GetFilesByExtensions(root string, extensions []string) (ret []File, reterr error) {
+// GetFilesByExtensions walks the root for files with one of the extensions.
+// Files and directories that are excluded or ignored by an ignore file are skipped. The exclude has to be rooted
+// at or above the root for the ignore files of the directories above the root to be picked up.
+// The number of files with one of the extensions that each ignore file excluded is returned along with the files
+func GetFilesByExtensions(root string, extensions []string, exclude *Exclude) (ret []File, ignored map[string]int, reterr error) {
+	ignored = map[string]int{}
+
+	walk := exclude.forWalk(root)
+	if walk.Excludes(root, true) {
+		return ret, ignored, nil
+	}
+
+	// directories ignored by an ignore file are still walked to count the files that were ignored
+	ignoredDirs := map[string]string{}
 	reterr = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if !d.IsDir() {
-			if slices.Contains(extensions, filepath.Ext(d.Name())) {
-				fileSize, err := GetFileSize(path)
-				if err != nil {
-					return err
-				}
-				file := File{
-					Name:     GetTitleOfFile(d.Name()),
-					FileName: filepath.Base(d.Name()),
-					Path:     path,
-					Size:     fileSize,
-				}
-
-				ret = append(ret, file)
+		if source, ok := ignoredDirs[filepath.Dir(path)]; ok {
+			if d.IsDir() {
+				ignoredDirs[path] = source
+			} else if slices.Contains(extensions, filepath.Ext(d.Name())) {
+				ignored[source]++
+			}
+			return nil
+		}
+
+		if excluded, source := walk.match(path, d.IsDir()); excluded {
+			switch {
+			case source == "" && d.IsDir():
+				return filepath.SkipDir
+			case d.IsDir():
+				ignoredDirs[path] = source
+			case source != "" && slices.Contains(extensions, filepath.Ext(d.Name())):
+				ignored[source]++
 			}
+			return nil
+		}
+
+		if d.IsDir() {
+			walk.addIgnoreFile(path)
+			return nil
+		}
+
+		if slices.Contains(extensions, filepath.Ext(d.Name())) {
+			info, err := fileInfo(path, d)
+			if err != nil {
+				return err
+			}
+			file := File{
+				Name:     GetTitleOfFile(d.Name()),
+				FileName: filepath.Base(d.Name()),
+				Path:     path,
+				Size:     int64(math.Abs(float64(info.Size()))),
+				Modified: info.ModTime().UTC().Truncate(time.Microsecond),
+			}
+
+			ret = append(ret, file)
 		}
 
 		return nil
 	})
 
-	return ret, reterr
+	return ret, ignored, reterr
+}
+
+// fileInfo uses the info of the directory entry so that the file is not stat'ed again.
+// Symbolic links are followed so that the info is of the file that is linked to
+func fileInfo(path string, d fs.DirEntry) (fs.FileInfo, error) {
+	if d.Type()&fs.ModeSymlink != 0 {
+		return os.Stat(path)
+	}
+
+	return d.Info()
 }
 
 func FindNonExistentMedia(existingVideos []model.Media, files []File) []model.Media {
+	onDisk := make(map[string]bool, len(files))
+	for _, f := range files {
+		onDisk[f.Path] = true
+	}
+
 	nonExsistentVideos := []model.Media{}
 	for _, v := range existingVideos {
-		if !slices.ContainsFunc(files, func(mediaFile File) bool {
-			return mediaFile.Path == v.Path
-		}) {
+		if !onDisk[v.Path] {
 			nonExsistentVideos = append(nonExsistentVideos, v)
 		}
 	}
@@ -1,6 +1,8 @@
 package media_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
@@ -23,7 +25,12 @@ func compareFileArrays(t *testing.T, got, want []File) {
 }
 
 func Test_GetFilesByExtensions(t *testing.T) {
-	got, _ := GetFilesByExtensions("./test_data", []string{".toml"})
+	exclude, err := NewExclude("./test_data", nil)
+	if err != nil {
+		t.Fatalf("could not create exclude: %v", err)
+	}
+
+	got, _, _ := GetFilesByExtensions("./test_data", []string{".toml"}, exclude)
 
 	want := []File{
 		{
@@ -46,6 +53,24 @@ func Test_GetFilesByExtensions(t *testing.T) {
 	compareFileArrays(t, got, want)
 }
 
+func Test_GetFilesByExtensions_WithExclude_ShouldSkipExcludedFilesAndDirectories(t *testing.T) {
+	exclude, err := NewExclude("./test_data", []string{"folder_2/", "root_*.toml"})
+	if err != nil {
+		t.Fatalf("could not create exclude: %v", err)
+	}
+
+	got, _, _ := GetFilesByExtensions("./test_data", []string{".toml"}, exclude)
+
+	want := []File{
+		{
+			Path:     "test_data/folder_1/folder_1_file.toml",
+			FileName: "folder_1_file.toml",
+		},
+	}
+
+	compareFileArrays(t, got, want)
+}
+
 func Test_GetTitleOfFile_GivenAFileWithoutAnExtension_ShouldReturnOriginal(t *testing.T) {
 	filename := "some_filename_without_extension"
 	title := GetTitleOfFile(filename)
@@ -162,3 +187,106 @@ func Test_FindNonExistentVideos_WithAVideoThatDoesNotExist_ShouldReturnASliceCon
 		t.Error("Returned path did not match expected relative path")
 	}
 }
+
+func Test_IsInPath(t *testing.T) {
+	cases := []struct {
+		root, path string
+		want       bool
+	}{
+		{"/library", "/library", true},
+		{"/library", "/library/dir/video.mp4", true},
+		{"/library", "/library/..video.mp4", true},
+		{"/library", "/library2/video.mp4", false},
+		{"/library", "/", false},
+	}
+
+	for _, c := range cases {
+		if got := IsInPath(c.root, c.path); got != c.want {
+			t.Errorf("IsInPath(%v, %v): got %v but wanted %v", c.root, c.path, got, c.want)
+		}
+	}
+}
+
+// writeTestFile writes the content to the path along with the directories it is in and returns the path
+func writeTestFile(t *testing.T, path, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("could not create directory for %v: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("could not write %v: %v", path, err)
+	}
+
+	return path
+}
+
+func Test_GetFilesByExtensions_WithoutExclude_ShouldOnlyUseTheIgnoreFilesInTheRoot(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, IgnoreFileName), "*.part.mp4\n")
+	writeTestFile(t, filepath.Join(root, "movie.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movie.part.mp4"), "")
+
+	got, _, err := GetFilesByExtensions(root, []string{".mp4"}, nil)
+	if err != nil {
+		t.Fatalf("could not get files: %v", err)
+	}
+
+	compareFileArrays(t, got, []File{{Path: filepath.Join(root, "movie.mp4"), FileName: "movie.mp4"}})
+}
+
+func Test_GetFilesByExtensions_WithIgnoreFiles_ShouldSkipIgnoredFilesInSubfolders(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "video.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", IgnoreFileName), "Samples/\n*.part.mp4\n")
+	writeTestFile(t, filepath.Join(root, "movies", "movie.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "movie.part.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "Samples", "sample.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "Samples", "nested", "sample.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "sequel", "sequel.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "sequel", "sequel.part.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "sequel", IgnoreFileName), "!sequel.part.mp4\n")
+	writeTestFile(t, filepath.Join(root, "other", "Samples", "sample.mp4"), "")
+
+	exclude, err := NewExclude(root, nil)
+	if err != nil {
+		t.Fatalf("could not create exclude: %v", err)
+	}
+
+	got, ignored, err := GetFilesByExtensions(root, []string{".mp4"}, exclude)
+	if err != nil {
+		t.Fatalf("could not get files: %v", err)
+	}
+
+	want := []File{
+		{Path: filepath.Join(root, "movies", "movie.mp4"), FileName: "movie.mp4"},
+		{Path: filepath.Join(root, "movies", "sequel", "sequel.mp4"), FileName: "sequel.mp4"},
+		{Path: filepath.Join(root, "movies", "sequel", "sequel.part.mp4"), FileName: "sequel.part.mp4"},
+		{Path: filepath.Join(root, "other", "Samples", "sample.mp4"), FileName: "sample.mp4"},
+		{Path: filepath.Join(root, "video.mp4"), FileName: "video.mp4"},
+	}
+	compareFileArrays(t, got, want)
+
+	ignoreFile := filepath.Join(root, "movies", IgnoreFileName)
+	if len(ignored) != 1 || ignored[ignoreFile] != 3 {
+		t.Errorf("expected 3 files to be ignored by %v but got %v", ignoreFile, ignored)
+	}
+}
+
+func Test_GetFilesByExtensions_WithIgnoreFileAboveTheRoot_ShouldSkipIgnoredFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, IgnoreFileName), "*.part.mp4\n")
+	writeTestFile(t, filepath.Join(root, "movies", "movie.mp4"), "")
+	writeTestFile(t, filepath.Join(root, "movies", "movie.part.mp4"), "")
+
+	exclude, err := NewExclude(root, nil)
+	if err != nil {
+		t.Fatalf("could not create exclude: %v", err)
+	}
+
+	got, _, err := GetFilesByExtensions(filepath.Join(root, "movies"), []string{".mp4"}, exclude)
+	if err != nil {
+		t.Fatalf("could not get files: %v", err)
+	}
+
+	compareFileArrays(t, got, []File{{Path: filepath.Join(root, "movies", "movie.mp4"), FileName: "movie.mp4"}})
+}
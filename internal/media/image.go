@@ -0,0 +1,92 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+
+	errs "github.com/slugger7/exorcist/internal/errors"
+)
+
+// webpHeaderSize is the size of the RIFF header and the header of the first chunk with its dimensions
+const webpHeaderSize = 30
+
+// GetImageDimensions reads the width and height of an image from the header of the file
+// without decoding the rest of the image
+func GetImageDimensions(path string) (int, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, errs.BuildError(err, "error opening file")
+	}
+	defer file.Close()
+
+	header := make([]byte, webpHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0, 0, errs.BuildError(err, "could not read header of %v", path)
+	}
+	header = header[:n]
+
+	if isWebp(header) {
+		return webpDimensions(header)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, errs.BuildError(err, "could not seek to start of %v", path)
+	}
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return 0, 0, errs.BuildError(err, "could not decode image config of %v", path)
+	}
+
+	return config.Width, config.Height, nil
+}
+
+func isWebp(header []byte) bool {
+	return len(header) >= 12 &&
+		bytes.Equal(header[0:4], []byte("RIFF")) &&
+		bytes.Equal(header[8:12], []byte("WEBP"))
+}
+
+// webpDimensions reads the dimensions from the first chunk of a webp file.
+// The standard library does not decode webp so the chunk headers are read as described in
+// https://developers.google.com/speed/webp/docs/riff_container
+func webpDimensions(header []byte) (int, int, error) {
+	if len(header) < webpHeaderSize {
+		return 0, 0, fmt.Errorf("webp header is too short")
+	}
+
+	chunk := header[20:]
+	switch string(header[12:16]) {
+	case "VP8 ":
+		// lossy: 3 byte frame tag, 3 byte start code and then 14 bit width and height
+		if !bytes.Equal(chunk[3:6], []byte{0x9d, 0x01, 0x2a}) {
+			return 0, 0, fmt.Errorf("invalid VP8 start code")
+		}
+		width := int(binary.LittleEndian.Uint16(chunk[6:8]) & 0x3fff)
+		height := int(binary.LittleEndian.Uint16(chunk[8:10]) & 0x3fff)
+		return width, height, nil
+	case "VP8L":
+		// lossless: signature byte and then 14 bit width-1 and height-1
+		if chunk[0] != 0x2f {
+			return 0, 0, fmt.Errorf("invalid VP8L signature")
+		}
+		bits := binary.LittleEndian.Uint32(chunk[1:5])
+		width := int(bits&0x3fff) + 1
+		height := int((bits>>14)&0x3fff) + 1
+		return width, height, nil
+	case "VP8X":
+		// extended: 4 bytes of flags and then 24 bit canvas width-1 and height-1
+		width := int(uint32(chunk[4])|uint32(chunk[5])<<8|uint32(chunk[6])<<16) + 1
+		height := int(uint32(chunk[7])|uint32(chunk[8])<<8|uint32(chunk[9])<<16) + 1
+		return width, height, nil
+	default:
+		return 0, 0, fmt.Errorf("unknown webp chunk: %q", header[12:16])
+	}
+}
@@ -0,0 +1,68 @@
+package media_test
+
+import (
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/slugger7/exorcist/internal/media"
+)
+
+func writeImage(t *testing.T, name string, encode func(f *os.File, img image.Image) error) string {
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("could not create %v: %v", path, err)
+	}
+	defer f.Close()
+
+	if err := encode(f, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("could not encode %v: %v", path, err)
+	}
+
+	return path
+}
+
+func webpFile(chunk string, data []byte) []byte {
+	file := []byte("RIFF\x00\x00\x00\x00WEBP" + chunk + "\x00\x00\x00\x00")
+	return append(file, data...)
+}
+
+func Test_GetImageDimensions(t *testing.T) {
+	cases := map[string]string{
+		"png": writeImage(t, "image.png", func(f *os.File, img image.Image) error {
+			return png.Encode(f, img)
+		}),
+		"jpeg": writeImage(t, "image.jpg", func(f *os.File, img image.Image) error {
+			return jpeg.Encode(f, img, nil)
+		}),
+		// 14 bit width and height after the frame tag and start code
+		"webp lossy": writeTestFile(t, filepath.Join(t.TempDir(), "lossy.webp"), string(webpFile("VP8 ", []byte{0, 0, 0, 0x9d, 0x01, 0x2a, 64, 0, 48, 0}))),
+		// width-1 and height-1 packed into 14 bits each after the signature
+		"webp lossless": writeTestFile(t, filepath.Join(t.TempDir(), "lossless.webp"), string(webpFile("VP8L", []byte{0x2f, 63, (47 & 0x3) << 6, 47 >> 2, 0, 0, 0, 0, 0, 0}))),
+		// 24 bit width-1 and height-1 after the flags
+		"webp extended": writeTestFile(t, filepath.Join(t.TempDir(), "extended.webp"), string(webpFile("VP8X", []byte{0, 0, 0, 0, 63, 0, 0, 47, 0, 0}))),
+	}
+
+	for name, path := range cases {
+		width, height, err := GetImageDimensions(path)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if width != 64 || height != 48 {
+			t.Errorf("%v: got %vx%v but wanted 64x48", name, width, height)
+		}
+	}
+}
+
+func Test_GetImageDimensions_WithUnknownFormat_ShouldReturnError(t *testing.T) {
+	path := writeTestFile(t, filepath.Join(t.TempDir(), "image.png"), "not an image")
+
+	if _, _, err := GetImageDimensions(path); err == nil {
+		t.Error("expected an error but got nil")
+	}
+}
@@ -0,0 +1,161 @@
+package media
+
+import (
+	"encoding/binary"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"math/bits"
+	"os"
+	"slices"
+
+	errs "github.com/slugger7/exorcist/internal/errors"
+)
+
+// PerceptualHashSize is the width and height that images are reduced to before they are hashed
+const PerceptualHashSize = 32
+
+const perceptualHashBits = 8
+
+// PerceptualHash is a 64 bit DCT hash of an image. Images that look alike have hashes that differ in few bits
+// even when they were scaled or encoded differently
+func PerceptualHash(img image.Image) uint64 {
+	pixels := grayscale(img, PerceptualHashSize)
+	coefficients := dct2d(pixels, PerceptualHashSize)
+
+	// the lowest frequencies hold the structure of the image
+	low := make([]float64, 0, perceptualHashBits*perceptualHashBits)
+	for y := range perceptualHashBits {
+		for x := range perceptualHashBits {
+			low = append(low, coefficients[y*PerceptualHashSize+x])
+		}
+	}
+
+	// the first coefficient is the average brightness which would skew the median
+	sorted := slices.Clone(low[1:])
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+	for i, c := range low {
+		if c > median {
+			hash |= 1 << uint(i)
+		}
+	}
+
+	return hash
+}
+
+// PerceptualHashFile decodes the image and returns its perceptual hash
+func PerceptualHashFile(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, errs.BuildError(err, "could not open image: %v", path)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return 0, errs.BuildError(err, "could not decode image: %v", path)
+	}
+
+	return PerceptualHash(img), nil
+}
+
+// grayscale averages the luminance of the pixels of the image into a size by size grid
+func grayscale(img image.Image, size int) []float64 {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	pixels := make([]float64, size*size)
+	counts := make([]int, size*size)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			i := ((y-bounds.Min.Y)*size/height)*size + (x-bounds.Min.X)*size/width
+			pixels[i] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			counts[i]++
+		}
+	}
+
+	for i := range pixels {
+		if counts[i] > 0 {
+			pixels[i] /= float64(counts[i])
+		}
+	}
+
+	return pixels
+}
+
+// dct2d is the two dimensional type II discrete cosine transform of a size by size grid
+func dct2d(pixels []float64, size int) []float64 {
+	cosines := make([]float64, size*size)
+	for k := range size {
+		for n := range size {
+			cosines[k*size+n] = math.Cos(math.Pi / float64(size) * (float64(n) + 0.5) * float64(k))
+		}
+	}
+
+	rows := make([]float64, size*size)
+	for y := range size {
+		for k := range size {
+			sum := 0.0
+			for n := range size {
+				sum += pixels[y*size+n] * cosines[k*size+n]
+			}
+			rows[y*size+k] = sum
+		}
+	}
+
+	result := make([]float64, size*size)
+	for x := range size {
+		for k := range size {
+			sum := 0.0
+			for n := range size {
+				sum += rows[n*size+x] * cosines[k*size+n]
+			}
+			result[k*size+x] = sum
+		}
+	}
+
+	return result
+}
+
+// Fingerprint is the perceptual hashes of frames sampled at the same relative positions of a video
+type Fingerprint []uint64
+
+// Bytes encodes the fingerprint to be stored
+func (f Fingerprint) Bytes() []byte {
+	b := make([]byte, 0, len(f)*8)
+	for _, h := range f {
+		b = binary.BigEndian.AppendUint64(b, h)
+	}
+
+	return b
+}
+
+// FingerprintFromBytes decodes a stored fingerprint
+func FingerprintFromBytes(b []byte) Fingerprint {
+	f := make(Fingerprint, 0, len(b)/8)
+	for i := 0; i+8 <= len(b); i += 8 {
+		f = append(f, binary.BigEndian.Uint64(b[i:i+8]))
+	}
+
+	return f
+}
+
+// Similarity is the fraction of bits that are the same in the hashes of the frames of both fingerprints.
+// Fingerprints with a different number of frames have a similarity of 0
+func (f Fingerprint) Similarity(other Fingerprint) float64 {
+	if len(f) == 0 || len(f) != len(other) {
+		return 0
+	}
+
+	distance := 0
+	for i := range f {
+		distance += bits.OnesCount64(f[i] ^ other[i])
+	}
+
+	return 1 - float64(distance)/float64(len(f)*64)
+}
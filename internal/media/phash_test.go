@@ -0,0 +1,68 @@
+package media_test
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+	"testing"
+
+	. "github.com/slugger7/exorcist/internal/media"
+)
+
+// pattern draws the same picture at any size
+func pattern(width, height int, invert bool) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			v := uint8(255 * x / width)
+			if y*2 > height {
+				v = 255 - v
+			}
+			if invert {
+				v = 255 - v
+			}
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	return img
+}
+
+func Test_PerceptualHash_ShouldMatchScaledImages(t *testing.T) {
+	small := PerceptualHash(pattern(320, 180, false))
+	large := PerceptualHash(pattern(1920, 1080, false))
+
+	if distance := bits.OnesCount64(small ^ large); distance > 4 {
+		t.Errorf("expected scaled images to have about the same hash but %v bits differed", distance)
+	}
+}
+
+func Test_PerceptualHash_ShouldNotMatchDifferentImages(t *testing.T) {
+	img := PerceptualHash(pattern(640, 360, false))
+	inverted := PerceptualHash(pattern(640, 360, true))
+
+	if distance := bits.OnesCount64(img ^ inverted); distance < 16 {
+		t.Errorf("expected different images to have different hashes but only %v bits differed", distance)
+	}
+}
+
+func Test_Fingerprint_BytesRoundTrip(t *testing.T) {
+	fingerprint := Fingerprint{1, 0xdead_beef, 1 << 63}
+
+	actual := FingerprintFromBytes(fingerprint.Bytes())
+
+	if actual.Similarity(fingerprint) != 1 {
+		t.Errorf("expected %v but got %v", fingerprint, actual)
+	}
+}
+
+func Test_Fingerprint_Similarity(t *testing.T) {
+	a := Fingerprint{0, 0}
+	b := Fingerprint{0xffff, 0}
+
+	if s := a.Similarity(b); s != 1-16.0/128 {
+		t.Errorf("expected similarity of %v but got %v", 1-16.0/128, s)
+	}
+	if s := a.Similarity(Fingerprint{0}); s != 0 {
+		t.Errorf("expected fingerprints with a different number of frames to not be similar but got %v", s)
+	}
+}
@@ -12,6 +12,7 @@ package mock_imageRepository
 import (
 	reflect "reflect"
 
+	postgres "github.com/go-jet/jet/v2/postgres"
 	uuid "github.com/google/uuid"
 	model "github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	imageRepository "github.com/slugger7/exorcist/internal/repository/image"
@@ -86,3 +87,17 @@ func (mr *MockImageRepositoryMockRecorder) GetByMediaId(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMediaId", reflect.TypeOf((*MockImageRepository)(nil).GetByMediaId), arg0)
 }
+
+// UpdateByMediaId mocks base method.
+func (m_2 *MockImageRepository) UpdateByMediaId(m model.Image, columns postgres.ColumnList) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateByMediaId", m, columns)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateByMediaId indicates an expected call of UpdateByMediaId.
+func (mr *MockImageRepositoryMockRecorder) UpdateByMediaId(m, columns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByMediaId", reflect.TypeOf((*MockImageRepository)(nil).UpdateByMediaId), m, columns)
+}
@@ -11,9 +11,12 @@ package mock_jobRepository
 
 import (
 	reflect "reflect"
+	time "time"
 
+	uuid "github.com/google/uuid"
 	model "github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	dto "github.com/slugger7/exorcist/internal/dto"
+	models "github.com/slugger7/exorcist/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,27 +44,59 @@ func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockJobRepository) Cancel(id uuid.UUID) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", id)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockJobRepositoryMockRecorder) Cancel(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockJobRepository)(nil).Cancel), id)
+}
+
+// CancelByParent mocks base method.
+func (m *MockJobRepository) CancelByParent(parent uuid.UUID) ([]model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelByParent", parent)
+	ret0, _ := ret[0].([]model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelByParent indicates an expected call of CancelByParent.
+func (mr *MockJobRepositoryMockRecorder) CancelByParent(parent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelByParent", reflect.TypeOf((*MockJobRepository)(nil).CancelByParent), parent)
+}
+
 // CancelInprogress mocks base method.
-func (m *MockJobRepository) CancelInprogress() error {
+func (m *MockJobRepository) CancelInprogress(worker string, expiredBefore time.Time) ([]model.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelInprogress")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CancelInprogress", worker, expiredBefore)
+	ret0, _ := ret[0].([]model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CancelInprogress indicates an expected call of CancelInprogress.
-func (mr *MockJobRepositoryMockRecorder) CancelInprogress() *gomock.Call {
+func (mr *MockJobRepositoryMockRecorder) CancelInprogress(worker, expiredBefore any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInprogress", reflect.TypeOf((*MockJobRepository)(nil).CancelInprogress))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInprogress", reflect.TypeOf((*MockJobRepository)(nil).CancelInprogress), worker, expiredBefore)
 }
 
 // CreateAll mocks base method.
-func (m *MockJobRepository) CreateAll(jobs []model.Job) ([]model.Job, error) {
+func (m *MockJobRepository) CreateAll(jobs []model.Job) ([]model.Job, []model.Job, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateAll", jobs)
 	ret0, _ := ret[0].([]model.Job)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].([]model.Job)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // CreateAll indicates an expected call of CreateAll.
@@ -71,10 +106,10 @@ func (mr *MockJobRepositoryMockRecorder) CreateAll(jobs any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockJobRepository) GetAll(arg0 dto.JobSearchDTO) (*dto.PageDTO[model.Job], error) {
+func (m *MockJobRepository) GetAll(arg0 dto.JobSearchDTO) (*dto.PageDTO[models.JobOverviewModel], error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", arg0)
-	ret0, _ := ret[0].(*dto.PageDTO[model.Job])
+	ret0, _ := ret[0].(*dto.PageDTO[models.JobOverviewModel])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -85,19 +120,108 @@ func (mr *MockJobRepositoryMockRecorder) GetAll(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockJobRepository)(nil).GetAll), arg0)
 }
 
+// GetById mocks base method.
+func (m *MockJobRepository) GetById(id uuid.UUID) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockJobRepositoryMockRecorder) GetById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockJobRepository)(nil).GetById), id)
+}
+
+// GetCancelledIds mocks base method.
+func (m *MockJobRepository) GetCancelledIds(ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancelledIds", ids)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCancelledIds indicates an expected call of GetCancelledIds.
+func (mr *MockJobRepositoryMockRecorder) GetCancelledIds(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancelledIds", reflect.TypeOf((*MockJobRepository)(nil).GetCancelledIds), ids)
+}
+
+// GetDescendantStatusCounts mocks base method.
+func (m *MockJobRepository) GetDescendantStatusCounts(id uuid.UUID) (map[model.JobStatusEnum]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDescendantStatusCounts", id)
+	ret0, _ := ret[0].(map[model.JobStatusEnum]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDescendantStatusCounts indicates an expected call of GetDescendantStatusCounts.
+func (mr *MockJobRepositoryMockRecorder) GetDescendantStatusCounts(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDescendantStatusCounts", reflect.TypeOf((*MockJobRepository)(nil).GetDescendantStatusCounts), id)
+}
+
 // GetNextJob mocks base method.
-func (m *MockJobRepository) GetNextJob() (*model.Job, error) {
+func (m *MockJobRepository) GetNextJob(excludedTypes []model.JobTypeEnum, worker string) (*model.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNextJob")
+	ret := m.ctrl.Call(m, "GetNextJob", excludedTypes, worker)
 	ret0, _ := ret[0].(*model.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNextJob indicates an expected call of GetNextJob.
-func (mr *MockJobRepositoryMockRecorder) GetNextJob() *gomock.Call {
+func (mr *MockJobRepositoryMockRecorder) GetNextJob(excludedTypes, worker any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextJob", reflect.TypeOf((*MockJobRepository)(nil).GetNextJob))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextJob", reflect.TypeOf((*MockJobRepository)(nil).GetNextJob), excludedTypes, worker)
+}
+
+// Heartbeat mocks base method.
+func (m *MockJobRepository) Heartbeat(ids []uuid.UUID, worker string) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", ids, worker)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockJobRepositoryMockRecorder) Heartbeat(ids, worker any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockJobRepository)(nil).Heartbeat), ids, worker)
+}
+
+// ResumeInprogress mocks base method.
+func (m *MockJobRepository) ResumeInprogress(jobTypes []model.JobTypeEnum, worker string, expiredBefore time.Time) ([]model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeInprogress", jobTypes, worker, expiredBefore)
+	ret0, _ := ret[0].([]model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeInprogress indicates an expected call of ResumeInprogress.
+func (mr *MockJobRepositoryMockRecorder) ResumeInprogress(jobTypes, worker, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeInprogress", reflect.TypeOf((*MockJobRepository)(nil).ResumeInprogress), jobTypes, worker, expiredBefore)
+}
+
+// Retry mocks base method.
+func (m *MockJobRepository) Retry(job *model.Job, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", job, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobRepositoryMockRecorder) Retry(job, delay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobRepository)(nil).Retry), job, delay)
 }
 
 // UpdateJobStatus mocks base method.
@@ -113,3 +237,17 @@ func (mr *MockJobRepositoryMockRecorder) UpdateJobStatus(model any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatus", reflect.TypeOf((*MockJobRepository)(nil).UpdateJobStatus), model)
 }
+
+// UpdateProgress mocks base method.
+func (m *MockJobRepository) UpdateProgress(job *model.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockJobRepositoryMockRecorder) UpdateProgress(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockJobRepository)(nil).UpdateProgress), job)
+}
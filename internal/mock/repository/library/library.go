@@ -12,6 +12,7 @@ package mock_libraryRepository
 import (
 	reflect "reflect"
 
+	postgres "github.com/go-jet/jet/v2/postgres"
 	uuid "github.com/google/uuid"
 	model "github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	dto "github.com/slugger7/exorcist/internal/dto"
@@ -119,16 +120,16 @@ func (mr *MockLibraryRepositoryMockRecorder) GetMedia(id, userId, search any) *g
 }
 
 // Update mocks base method.
-func (m_2 *MockLibraryRepository) Update(m model.Library) (*model.Library, error) {
+func (m_2 *MockLibraryRepository) Update(m model.Library, columns postgres.ColumnList) (*model.Library, error) {
 	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "Update", m)
+	ret := m_2.ctrl.Call(m_2, "Update", m, columns)
 	ret0, _ := ret[0].(*model.Library)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockLibraryRepositoryMockRecorder) Update(m any) *gomock.Call {
+func (mr *MockLibraryRepositoryMockRecorder) Update(m, columns any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLibraryRepository)(nil).Update), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLibraryRepository)(nil).Update), m, columns)
 }
@@ -115,3 +115,33 @@ func (mr *MockLibraryPathRepositoryMockRecorder) GetContainingPath(path any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainingPath", reflect.TypeOf((*MockLibraryPathRepository)(nil).GetContainingPath), path)
 }
+
+// GetWatched mocks base method.
+func (m *MockLibraryPathRepository) GetWatched() ([]model.LibraryPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatched")
+	ret0, _ := ret[0].([]model.LibraryPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatched indicates an expected call of GetWatched.
+func (mr *MockLibraryPathRepositoryMockRecorder) GetWatched() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatched", reflect.TypeOf((*MockLibraryPathRepository)(nil).GetWatched))
+}
+
+// Update mocks base method.
+func (m_2 *MockLibraryPathRepository) Update(m model.LibraryPath) (*model.LibraryPath, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Update", m)
+	ret0, _ := ret[0].(*model.LibraryPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLibraryPathRepositoryMockRecorder) Update(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLibraryPathRepository)(nil).Update), m)
+}
@@ -163,6 +163,51 @@ func (mr *MockMediaRepositoryMockRecorder) GetByLibraryPathId(id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLibraryPathId", reflect.TypeOf((*MockMediaRepository)(nil).GetByLibraryPathId), id)
 }
 
+// GetDuplicates mocks base method.
+func (m *MockMediaRepository) GetDuplicates(by dto.DuplicateKey) ([]models.DuplicateMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", by)
+	ret0, _ := ret[0].([]models.DuplicateMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockMediaRepositoryMockRecorder) GetDuplicates(by any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockMediaRepository)(nil).GetDuplicates), by)
+}
+
+// GetFingerprinted mocks base method.
+func (m *MockMediaRepository) GetFingerprinted() ([]models.DuplicateMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFingerprinted")
+	ret0, _ := ret[0].([]models.DuplicateMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFingerprinted indicates an expected call of GetFingerprinted.
+func (mr *MockMediaRepositoryMockRecorder) GetFingerprinted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFingerprinted", reflect.TypeOf((*MockMediaRepository)(nil).GetFingerprinted))
+}
+
+// GetMissing mocks base method.
+func (m *MockMediaRepository) GetMissing() ([]model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissing")
+	ret0, _ := ret[0].([]model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissing indicates an expected call of GetMissing.
+func (mr *MockMediaRepositoryMockRecorder) GetMissing() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissing", reflect.TypeOf((*MockMediaRepository)(nil).GetMissing))
+}
+
 // GetProgressForUser mocks base method.
 func (m *MockMediaRepository) GetProgressForUser(id, userId uuid.UUID) (*model.MediaProgress, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +252,20 @@ func (mr *MockMediaRepositoryMockRecorder) RemoveRelation(id, relatedTo any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRelation", reflect.TypeOf((*MockMediaRepository)(nil).RemoveRelation), id, relatedTo)
 }
 
+// ResolveDuplicates mocks base method.
+func (m *MockMediaRepository) ResolveDuplicates(keepId uuid.UUID, duplicateIds []uuid.UUID, deleted []model.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDuplicates", keepId, duplicateIds, deleted)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveDuplicates indicates an expected call of ResolveDuplicates.
+func (mr *MockMediaRepositoryMockRecorder) ResolveDuplicates(keepId, duplicateIds, deleted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDuplicates", reflect.TypeOf((*MockMediaRepository)(nil).ResolveDuplicates), keepId, duplicateIds, deleted)
+}
+
 // Update mocks base method.
 func (m_2 *MockMediaRepository) Update(m model.Media, columns postgres.ColumnList) (*model.Media, error) {
 	m_2.ctrl.T.Helper()
@@ -12,8 +12,11 @@ package mock_repository
 import (
 	reflect "reflect"
 
+	pq "github.com/lib/pq"
 	imageRepository "github.com/slugger7/exorcist/internal/repository/image"
 	jobRepository "github.com/slugger7/exorcist/internal/repository/job"
+	jobLogRepository "github.com/slugger7/exorcist/internal/repository/job_log"
+	jobScheduleRepository "github.com/slugger7/exorcist/internal/repository/job_schedule"
 	libraryRepository "github.com/slugger7/exorcist/internal/repository/library"
 	libraryPathRepository "github.com/slugger7/exorcist/internal/repository/library_path"
 	mediaRepository "github.com/slugger7/exorcist/internal/repository/media"
@@ -105,6 +108,34 @@ func (mr *MockRepositoryMockRecorder) Job() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Job", reflect.TypeOf((*MockRepository)(nil).Job))
 }
 
+// JobLog mocks base method.
+func (m *MockRepository) JobLog() jobLogRepository.JobLogRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JobLog")
+	ret0, _ := ret[0].(jobLogRepository.JobLogRepository)
+	return ret0
+}
+
+// JobLog indicates an expected call of JobLog.
+func (mr *MockRepositoryMockRecorder) JobLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobLog", reflect.TypeOf((*MockRepository)(nil).JobLog))
+}
+
+// JobSchedule mocks base method.
+func (m *MockRepository) JobSchedule() jobScheduleRepository.JobScheduleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JobSchedule")
+	ret0, _ := ret[0].(jobScheduleRepository.JobScheduleRepository)
+	return ret0
+}
+
+// JobSchedule indicates an expected call of JobSchedule.
+func (mr *MockRepositoryMockRecorder) JobSchedule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobSchedule", reflect.TypeOf((*MockRepository)(nil).JobSchedule))
+}
+
 // Library mocks base method.
 func (m *MockRepository) Library() libraryRepository.LibraryRepository {
 	m.ctrl.T.Helper()
@@ -133,6 +164,21 @@ func (mr *MockRepositoryMockRecorder) LibraryPath() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LibraryPath", reflect.TypeOf((*MockRepository)(nil).LibraryPath))
 }
 
+// Listen mocks base method.
+func (m *MockRepository) Listen(channel string) (*pq.Listener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", channel)
+	ret0, _ := ret[0].(*pq.Listener)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockRepositoryMockRecorder) Listen(channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockRepository)(nil).Listen), channel)
+}
+
 // Media mocks base method.
 func (m *MockRepository) Media() mediaRepository.MediaRepository {
 	m.ctrl.T.Helper()
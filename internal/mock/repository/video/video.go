@@ -12,6 +12,7 @@ package mock_videoRepository
 import (
 	reflect "reflect"
 
+	postgres "github.com/go-jet/jet/v2/postgres"
 	uuid "github.com/google/uuid"
 	model "github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	videoRepository "github.com/slugger7/exorcist/internal/repository/video"
@@ -101,3 +102,17 @@ func (mr *MockVideoRepositoryMockRecorder) Insert(models any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockVideoRepository)(nil).Insert), models)
 }
+
+// UpdateByMediaId mocks base method.
+func (m_2 *MockVideoRepository) UpdateByMediaId(m model.Video, columns postgres.ColumnList) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateByMediaId", m, columns)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateByMediaId indicates an expected call of UpdateByMediaId.
+func (mr *MockVideoRepositoryMockRecorder) UpdateByMediaId(m, columns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByMediaId", reflect.TypeOf((*MockVideoRepository)(nil).UpdateByMediaId), m, columns)
+}
@@ -15,6 +15,7 @@ import (
 	uuid "github.com/google/uuid"
 	model "github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	dto "github.com/slugger7/exorcist/internal/dto"
+	models "github.com/slugger7/exorcist/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -86,6 +87,36 @@ func (mr *MockMediaServiceMockRecorder) Delete(id, physical any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaService)(nil).Delete), id, physical)
 }
 
+// GetDuplicates mocks base method.
+func (m *MockMediaService) GetDuplicates(by dto.DuplicateKey) ([][]models.DuplicateMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicates", by)
+	ret0, _ := ret[0].([][]models.DuplicateMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicates indicates an expected call of GetDuplicates.
+func (mr *MockMediaServiceMockRecorder) GetDuplicates(by any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicates", reflect.TypeOf((*MockMediaService)(nil).GetDuplicates), by)
+}
+
+// GetNearDuplicates mocks base method.
+func (m *MockMediaService) GetNearDuplicates(threshold float64) ([]models.NearDuplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearDuplicates", threshold)
+	ret0, _ := ret[0].([]models.NearDuplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearDuplicates indicates an expected call of GetNearDuplicates.
+func (mr *MockMediaServiceMockRecorder) GetNearDuplicates(threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearDuplicates", reflect.TypeOf((*MockMediaService)(nil).GetNearDuplicates), threshold)
+}
+
 // LogProgress mocks base method.
 func (m *MockMediaService) LogProgress(id, userId uuid.UUID, progress dto.ProgressUpdateDTO) (*model.MediaProgress, error) {
 	m.ctrl.T.Helper()
@@ -100,3 +131,17 @@ func (mr *MockMediaServiceMockRecorder) LogProgress(id, userId, progress any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogProgress", reflect.TypeOf((*MockMediaService)(nil).LogProgress), id, userId, progress)
 }
+
+// ResolveDuplicates mocks base method.
+func (m *MockMediaService) ResolveDuplicates(resolve dto.ResolveDuplicatesDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDuplicates", resolve)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveDuplicates indicates an expected call of ResolveDuplicates.
+func (mr *MockMediaServiceMockRecorder) ResolveDuplicates(resolve any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDuplicates", reflect.TypeOf((*MockMediaService)(nil).ResolveDuplicates), resolve)
+}
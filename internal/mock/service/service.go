@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	jobService "github.com/slugger7/exorcist/internal/service/job"
+	jobScheduleService "github.com/slugger7/exorcist/internal/service/job_schedule"
 	libraryService "github.com/slugger7/exorcist/internal/service/library"
 	libraryPathService "github.com/slugger7/exorcist/internal/service/library_path"
 	mediaService "github.com/slugger7/exorcist/internal/service/media"
@@ -61,6 +62,20 @@ func (mr *MockServiceMockRecorder) Job() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Job", reflect.TypeOf((*MockService)(nil).Job))
 }
 
+// JobSchedule mocks base method.
+func (m *MockService) JobSchedule() jobScheduleService.JobScheduleService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JobSchedule")
+	ret0, _ := ret[0].(jobScheduleService.JobScheduleService)
+	return ret0
+}
+
+// JobSchedule indicates an expected call of JobSchedule.
+func (mr *MockServiceMockRecorder) JobSchedule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobSchedule", reflect.TypeOf((*MockService)(nil).JobSchedule))
+}
+
 // Library mocks base method.
 func (m *MockService) Library() libraryService.LibraryService {
 	m.ctrl.T.Helper()
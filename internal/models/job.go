@@ -0,0 +1,15 @@
+package models
+
+import "github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+
+type JobTree struct {
+	Job model.Job
+	// Descendants holds the number of jobs below the job by status
+	Descendants map[model.JobStatusEnum]int
+}
+
+type JobOverviewModel struct {
+	model.Job
+	// EffectivePriority is the priority of the job after it has been aged by the time it has been waiting
+	EffectivePriority int16
+}
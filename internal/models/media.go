@@ -38,3 +38,18 @@ type Media struct {
 	Tags     []model.Tag
 	Chapters []MediaChapter
 }
+
+// DuplicateMedia is media that has the same checksum, or size and runtime, as other media
+type DuplicateMedia struct {
+	model.Media
+	*model.Video
+	*model.Image
+	model.Library
+}
+
+// NearDuplicate is a pair of videos with fingerprints that are alike
+type NearDuplicate struct {
+	A          DuplicateMedia
+	B          DuplicateMedia
+	Similarity float64
+}
@@ -22,6 +22,7 @@ type ImageRepository interface {
 	Create(m *model.Image) (*model.Image, error)
 	GetById(uuid.UUID) (*MediaImage, error)
 	GetByMediaId(uuid.UUID) (*MediaImage, error)
+	UpdateByMediaId(m model.Image, columns postgres.ColumnList) error
 }
 
 type imageRepository struct {
@@ -45,12 +46,16 @@ func (i *imageRepository) GetByMediaId(id uuid.UUID) (*MediaImage, error) {
 
 	util.DebugCheck(i.env, statement)
 
-	var result MediaImage
-	if err := statement.QueryContext(i.ctx, i.db, &result); err != nil {
+	var results []MediaImage
+	if err := statement.QueryContext(i.ctx, i.db, &results); err != nil {
 		return nil, errs.BuildError(err, "could not get image by media id: %v", id)
 	}
 
-	return &result, nil
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &results[0], nil
 }
 
 var imageRepoInstance *imageRepository
@@ -90,18 +95,46 @@ func (i *imageRepository) Create(m *model.Image) (*model.Image, error) {
 }
 
 func (i *imageRepository) GetById(id uuid.UUID) (*MediaImage, error) {
-	var img MediaImage
-	statement := table.Image.SELECT(table.Image.AllColumns, table.Media.AllColumns).
-		FROM(table.Image.INNER_JOIN(
-			table.Image,
-			table.Image.MediaID.EQ(table.Media.ID),
+	media := table.Media
+	image := table.Image
+
+	statement := image.SELECT(image.AllColumns, media.AllColumns).
+		FROM(image.INNER_JOIN(
+			media,
+			image.MediaID.EQ(media.ID),
 		)).
-		WHERE(table.Image.ID.EQ(postgres.UUID(id))).
+		WHERE(image.ID.EQ(postgres.UUID(id))).
 		LIMIT(1)
 
-	if err := statement.Query(i.db, &img); err != nil {
+	util.DebugCheck(i.env, statement)
+
+	var results []MediaImage
+	if err := statement.QueryContext(i.ctx, i.db, &results); err != nil {
 		return nil, errs.BuildError(err, "error getting image by id")
 	}
 
-	return &img, nil
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &results[0], nil
+}
+
+// UpdateByMediaId updates the columns of the image of the media
+func (i *imageRepository) UpdateByMediaId(m model.Image, columns postgres.ColumnList) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	statement := table.Image.UPDATE(columns).
+		MODEL(m).
+		WHERE(table.Image.MediaID.EQ(postgres.UUID(m.MediaID)))
+
+	util.DebugCheck(i.env, statement)
+
+	if _, err := statement.ExecContext(i.ctx, i.db); err != nil {
+		return errs.BuildError(err, "could not update image of media: %v", m.MediaID)
+	}
+
+	return nil
 }
@@ -3,6 +3,9 @@ package jobRepository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
@@ -12,6 +15,7 @@ import (
 	"github.com/slugger7/exorcist/internal/dto"
 	"github.com/slugger7/exorcist/internal/environment"
 	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/models"
 	"github.com/slugger7/exorcist/internal/repository/util"
 )
 
@@ -22,13 +26,25 @@ type JobStatement struct {
 }
 
 type JobRepository interface {
-	CreateAll(jobs []model.Job) ([]model.Job, error)
-	GetNextJob() (*model.Job, error)
+	CreateAll(jobs []model.Job) ([]model.Job, []model.Job, error)
+	GetNextJob(excludedTypes []model.JobTypeEnum, worker string) (*model.Job, error)
 	UpdateJobStatus(model *model.Job) error
-	GetAll(dto.JobSearchDTO) (*dto.PageDTO[model.Job], error)
-	CancelInprogress() error
+	GetAll(dto.JobSearchDTO) (*dto.PageDTO[models.JobOverviewModel], error)
+	CancelInprogress(worker string, expiredBefore time.Time) ([]model.Job, error)
+	ResumeInprogress(jobTypes []model.JobTypeEnum, worker string, expiredBefore time.Time) ([]model.Job, error)
+	Heartbeat(ids []uuid.UUID, worker string) ([]uuid.UUID, error)
+	GetById(id uuid.UUID) (*model.Job, error)
+	Cancel(id uuid.UUID) (*model.Job, error)
+	CancelByParent(parent uuid.UUID) ([]model.Job, error)
+	GetCancelledIds(ids []uuid.UUID) ([]uuid.UUID, error)
+	Retry(job *model.Job, delay time.Duration) error
+	UpdateProgress(job *model.Job) error
+	GetDescendantStatusCounts(id uuid.UUID) (map[model.JobStatusEnum]int, error)
 }
 
+// ErrJobNotInProgress is returned when a job that finished is no longer in progress as it was cancelled while it was finishing
+var ErrJobNotInProgress = errors.New("job is no longer in progress")
+
 type jobRepository struct {
 	db  *sql.DB
 	env *environment.EnvironmentVariables
@@ -36,26 +52,214 @@ type jobRepository struct {
 }
 
 // CancelInprogress implements JobRepository.
-func (j *jobRepository) CancelInprogress() error {
+// Cancels in progress jobs of the worker and jobs whose heartbeat expired before the given time
+func (j *jobRepository) CancelInprogress(worker string, expiredBefore time.Time) ([]model.Job, error) {
 	mod := time.Now()
-	outcome := "cancelled at startup"
+	outcome := cancelledAtStartupOutcome
 	statement := table.Job.UPDATE(table.Job.Status, table.Job.Modified, table.Job.Outcome).
 		MODEL(model.Job{
 			Status:   model.JobStatusEnum_Cancelled,
 			Modified: mod,
 			Outcome:  &outcome,
 		}).
-		WHERE(table.Job.Status.EQ(postgres.NewEnumValue(string(model.JobStatusEnum_InProgress))))
+		WHERE(interruptedExpression(worker, expiredBefore)).
+		RETURNING(table.Job.AllColumns)
+
+	util.DebugCheck(j.env, statement)
+
+	var jobs []struct{ model.Job }
+	if err := statement.QueryContext(j.ctx, j.db, &jobs); err != nil {
+		return nil, errs.BuildError(err, "updating in progress jobs to cancelled")
+	}
+
+	cancelled := make([]model.Job, len(jobs))
+	for i, o := range jobs {
+		cancelled[i] = o.Job
+	}
+
+	return cancelled, nil
+}
+
+// ResumeInprogress implements JobRepository.
+// Puts interrupted jobs of the given types back in the queue. The interrupted attempt does not count towards the attempts of the job
+func (j *jobRepository) ResumeInprogress(jobTypes []model.JobTypeEnum, worker string, expiredBefore time.Time) ([]model.Job, error) {
+	if len(jobTypes) == 0 {
+		return []model.Job{}, nil
+	}
+
+	var jobs []struct{ model.Job }
+	if err := j.resumeInprogressStatement(jobTypes, worker, expiredBefore).Query(&jobs); err != nil {
+		return nil, errs.BuildError(err, "updating in progress jobs to not started")
+	}
+
+	resumed := make([]model.Job, len(jobs))
+	for i, o := range jobs {
+		resumed[i] = o.Job
+	}
+
+	return resumed, nil
+}
+
+// jobErrorOutcome is the outcome of cancelled jobs in the same form as the errors of failed jobs
+func jobErrorOutcome(message string) string {
+	data, _ := json.Marshal(models.JobError{Error: message})
+	return string(data)
+}
+
+var cancelledOutcome = jobErrorOutcome("cancelled by user")
+
+var cancelledAtStartupOutcome = jobErrorOutcome("cancelled at startup")
+
+// Cancel implements JobRepository.
+// Only jobs that have not finished yet can be cancelled. Nil is returned when there was no job to cancel.
+func (j *jobRepository) Cancel(id uuid.UUID) (*model.Job, error) {
+	var jobs []struct{ model.Job }
+	if err := j.cancelStatement(table.Job.ID.EQ(postgres.UUID(id))).Query(&jobs); err != nil {
+		return nil, errs.BuildError(err, "could not cancel job %v", id.String())
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0].Job, nil
+}
+
+// CancelByParent implements JobRepository.
+func (j *jobRepository) CancelByParent(parent uuid.UUID) ([]model.Job, error) {
+	var jobsStruct []struct{ model.Job }
+	if err := j.cancelStatement(table.Job.Parent.EQ(postgres.UUID(parent))).Query(&jobsStruct); err != nil {
+		return nil, errs.BuildError(err, "could not cancel jobs with parent %v", parent.String())
+	}
+
+	jobs := make([]model.Job, len(jobsStruct))
+	for i, o := range jobsStruct {
+		jobs[i] = o.Job
+	}
+
+	return jobs, nil
+}
+
+// GetCancelledIds implements JobRepository.
+func (j *jobRepository) GetCancelledIds(ids []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	idExpressions := make([]postgres.Expression, len(ids))
+	for i, id := range ids {
+		idExpressions[i] = postgres.UUID(id)
+	}
+
+	statement := table.Job.SELECT(table.Job.ID).
+		FROM(table.Job).
+		WHERE(table.Job.ID.IN(idExpressions...).
+			AND(table.Job.Status.EQ(postgres.NewEnumValue(string(model.JobStatusEnum_Cancelled)))))
 
 	util.DebugCheck(j.env, statement)
 
-	if _, err := statement.ExecContext(j.ctx, j.db); err != nil {
-		return errs.BuildError(err, "updating in progress jobs to cancelled")
+	var jobs []struct{ model.Job }
+	if err := statement.QueryContext(j.ctx, j.db, &jobs); err != nil {
+		return nil, errs.BuildError(err, "could not get cancelled jobs")
 	}
 
+	cancelled := make([]uuid.UUID, len(jobs))
+	for i, o := range jobs {
+		cancelled[i] = o.Job.ID
+	}
+
+	return cancelled, nil
+}
+
+// Retry implements JobRepository.
+// The job is moved back to not started and will only be picked up again after the delay has passed.
+func (j *jobRepository) Retry(job *model.Job, delay time.Duration) error {
+	job.Status = model.JobStatusEnum_NotStarted
+	job.Modified = time.Now()
+
+	var updated []struct{ model.Job }
+	if err := j.retryStatement(job, delay).Query(&updated); err != nil {
+		return errs.BuildError(err, "could not schedule retry for job %v", job.ID.String())
+	}
+
+	if len(updated) == 0 {
+		return fmt.Errorf("%w: %v", ErrJobNotInProgress, job.ID.String())
+	}
+	job.RunAfter = updated[0].Job.RunAfter
+
 	return nil
 }
 
+// Heartbeat implements JobRepository.
+// Returns the ids of the jobs that are still owned by the worker
+func (j *jobRepository) Heartbeat(ids []uuid.UUID, worker string) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	var jobs []struct{ model.Job }
+	if err := j.heartbeatStatement(ids, worker).Query(&jobs); err != nil {
+		return nil, errs.BuildError(err, "could not update heartbeat of jobs")
+	}
+
+	alive := make([]uuid.UUID, len(jobs))
+	for i, o := range jobs {
+		alive[i] = o.Job.ID
+	}
+
+	return alive, nil
+}
+
+// UpdateProgress implements JobRepository.
+// Only the progress columns are written so that status changes are never overwritten
+func (j *jobRepository) UpdateProgress(job *model.Job) error {
+	if _, err := j.updateProgressStatement(job).Exec(); err != nil {
+		return errs.BuildError(err, "could not update progress of job %v", job.ID.String())
+	}
+
+	return nil
+}
+
+// GetDescendantStatusCounts implements JobRepository.
+// Counts the jobs in the whole tree below a job by their status
+func (j *jobRepository) GetDescendantStatusCounts(id uuid.UUID) (map[model.JobStatusEnum]int, error) {
+	var results []struct {
+		Status model.JobStatusEnum
+		Count  int
+	}
+	if err := j.descendantStatusCountsStatement(id).Query(&results); err != nil {
+		return nil, errs.BuildError(err, "could not count descendants of job %v", id.String())
+	}
+
+	counts := map[model.JobStatusEnum]int{}
+	for _, r := range results {
+		counts[r.Status] = r.Count
+	}
+
+	return counts, nil
+}
+
+// GetById implements JobRepository.
+func (j *jobRepository) GetById(id uuid.UUID) (*model.Job, error) {
+	statement := table.Job.SELECT(table.Job.AllColumns).
+		FROM(table.Job).
+		WHERE(table.Job.ID.EQ(postgres.UUID(id))).
+		LIMIT(1)
+
+	util.DebugCheck(j.env, statement)
+
+	var jobs []struct{ model.Job }
+	if err := statement.QueryContext(j.ctx, j.db, &jobs); err != nil {
+		return nil, errs.BuildError(err, "could not get job by id %v", id.String())
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0].Job, nil
+}
+
 var jobRepoInstance *jobRepository
 
 func New(db *sql.DB, env *environment.EnvironmentVariables, context context.Context) JobRepository {
@@ -70,26 +274,66 @@ func New(db *sql.DB, env *environment.EnvironmentVariables, context context.Cont
 	return jobRepoInstance
 }
 
-func (j *jobRepository) CreateAll(jobs []model.Job) ([]model.Job, error) {
+// CreateAll implements JobRepository.
+// Jobs that would do the same work as a job that is pending or running are not created.
+// The created jobs are returned along with the jobs that were skipped
+func (j *jobRepository) CreateAll(jobs []model.Job) ([]model.Job, []model.Job, error) {
 	if len(jobs) == 0 {
-		return jobs, nil
+		return jobs, []model.Job{}, nil
 	}
+
+	toCreate, skipped := dedupJobs(jobs)
+
 	var newJobs []struct{ model.Job }
-	if err := j.createAllStatement(jobs).Query(&newJobs); err != nil {
-		return nil, errs.BuildError(err, "error when creating jobs")
+	if err := j.createAllStatement(toCreate).Query(&newJobs); err != nil {
+		return nil, nil, errs.BuildError(err, "error when creating jobs")
 	}
 
+	created := map[string]bool{}
 	jobModels := []model.Job{}
 	for _, j := range newJobs {
+		if j.DedupKey != nil {
+			created[*j.DedupKey] = true
+		}
 		jobModels = append(jobModels, j.Job)
 	}
 
-	return jobModels, nil
+	for _, j := range toCreate {
+		if j.DedupKey != nil && !created[*j.DedupKey] {
+			skipped = append(skipped, j)
+		}
+	}
+
+	return jobModels, skipped, nil
 }
 
-func (j *jobRepository) GetNextJob() (*model.Job, error) {
+// dedupJobs drops the jobs that repeat a dedup key within the batch.
+// The dedup key is set by the job registry when the job is built
+func dedupJobs(jobs []model.Job) ([]model.Job, []model.Job) {
+	seen := map[string]bool{}
+	toCreate := []model.Job{}
+	skipped := []model.Job{}
+	for _, j := range jobs {
+		if j.DedupKey != nil {
+			if seen[*j.DedupKey] {
+				skipped = append(skipped, j)
+				continue
+			}
+			seen[*j.DedupKey] = true
+		}
+
+		toCreate = append(toCreate, j)
+	}
+
+	return toCreate, skipped
+}
+
+// GetNextJob implements JobRepository.
+// Claims the next job that is not started for the worker by moving it to in progress in a single statement.
+// Jobs of the excluded types are not claimed and rows that are locked by other workers are skipped
+func (j *jobRepository) GetNextJob(excludedTypes []model.JobTypeEnum, worker string) (*model.Job, error) {
 	var job []struct{ model.Job }
-	if err := j.getNextJobStatement().Query(&job); err != nil {
+	if err := j.getNextJobStatement(excludedTypes, worker).Query(&job); err != nil {
 		return nil, errs.BuildError(err, "could not get next job")
 	}
 	if len(job) == 1 {
@@ -99,57 +343,28 @@ func (j *jobRepository) GetNextJob() (*model.Job, error) {
 	return nil, nil
 }
 
+// UpdateJobStatus implements JobRepository.
+// Only jobs that are in progress are updated so that a job that was cancelled while it was finishing stays cancelled
 func (j *jobRepository) UpdateJobStatus(model *model.Job) error {
 	model.Modified = time.Now()
-	if _, err := j.updateJobStatusStatement(model).Exec(); err != nil {
+	result, err := j.updateJobStatusStatement(model).Exec()
+	if err != nil {
 		return errs.BuildError(err, "could not update job %v status to %v", model.ID, model.Status)
 	}
 
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return fmt.Errorf("%w: %v", ErrJobNotInProgress, model.ID)
+	}
+
 	return nil
 }
 
-func (r *jobRepository) GetAll(m dto.JobSearchDTO) (*dto.PageDTO[model.Job], error) {
+func (r *jobRepository) GetAll(m dto.JobSearchDTO) (*dto.PageDTO[models.JobOverviewModel], error) {
 	if m.Limit == 0 {
 		m.Limit = 100
 	}
 
-	statement := table.Job.SELECT(table.Job.AllColumns).
-		FROM(table.Job).
-		ORDER_BY(m.OrderBy.ToColumn().DESC()).
-		LIMIT(int64(m.Limit)).
-		OFFSET(int64(m.Skip))
-
-	countStatement := table.Job.SELECT(postgres.COUNT(table.Job.ID).AS("total")).FROM(table.Job)
-
-	var whereExpression postgres.BoolExpression
-	if m.Parent == nil {
-		whereExpression = table.Job.Parent.IS_NULL()
-	} else {
-		id, _ := uuid.Parse(*m.Parent)
-		whereExpression = table.Job.Parent.EQ(postgres.UUID(id))
-	}
-
-	statusExpressions := make([]postgres.Expression, len(m.Statuses))
-	for i, s := range m.Statuses {
-		statusExpressions[i] = postgres.NewEnumValue(string(s))
-	}
-	if len(statusExpressions) > 0 {
-		whereExpression = whereExpression.AND(table.Job.Status.IN(statusExpressions...))
-	}
-
-	jobTypeExpression := make([]postgres.Expression, len(m.JobTypes))
-	for i, t := range m.JobTypes {
-		jobTypeExpression[i] = postgres.NewEnumValue(string(t))
-	}
-	if len(jobTypeExpression) > 0 {
-		whereExpression = whereExpression.AND(table.Job.JobType.IN(jobTypeExpression...))
-	}
-
-	statement = statement.WHERE(whereExpression)
-	countStatement = countStatement.WHERE(whereExpression)
-
-	util.DebugCheck(r.env, statement)
-	util.DebugCheck(r.env, countStatement)
+	statement, countStatement := r.getAllStatement(m)
 
 	var totalStruct struct {
 		Total int
@@ -158,17 +373,16 @@ func (r *jobRepository) GetAll(m dto.JobSearchDTO) (*dto.PageDTO[model.Job], err
 		return nil, errs.BuildError(err, "could not query jobs for total")
 	}
 
-	var jobsStruct []struct{ model.Job }
-	if err := statement.QueryContext(r.ctx, r.db, &jobsStruct); err != nil {
+	var jobs []models.JobOverviewModel
+	if err := statement.QueryContext(r.ctx, r.db, &jobs); err != nil {
 		return nil, errs.BuildError(err, "could not get jobs with %v", m)
 	}
 
-	var jobs = make([]model.Job, len(jobsStruct))
-	for i, j := range jobsStruct {
-		jobs[i] = j.Job
+	if jobs == nil {
+		jobs = []models.JobOverviewModel{}
 	}
 
-	return &dto.PageDTO[model.Job]{
+	return &dto.PageDTO[models.JobOverviewModel]{
 		Total: totalStruct.Total,
 		Limit: m.Limit,
 		Skip:  m.Skip,
@@ -2,10 +2,13 @@ package jobRepository
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/dto"
 	"github.com/slugger7/exorcist/internal/repository/util"
 )
 
@@ -17,9 +20,20 @@ func (js JobStatement) Exec() (sql.Result, error) {
 	return js.Statement.ExecContext(js.ctx, js.db)
 }
 
+// inProgress matches jobs that are running. Jobs that finished only have their status updated while they are in progress
+// so that the status of a job that was cancelled in the meantime is kept
+var inProgress = table.Job.Status.EQ(postgres.NewEnumValue(string(model.JobStatusEnum_InProgress)))
+
+// createAllStatement skips jobs with a dedup key that is already used by a job that is pending or running
 func (jb *jobRepository) createAllStatement(jobs []model.Job) JobStatement {
-	statement := table.JobTable.INSERT(*table.Job, table.Job.JobType, table.Job.Status, table.Job.Data, table.Job.Parent, table.Job.Priority).
+	statement := table.JobTable.INSERT(*table.Job, table.Job.JobType, table.Job.Status, table.Job.Data, table.Job.Parent, table.Job.Priority, table.Job.DedupKey).
 		MODELS(jobs).
+		ON_CONFLICT(table.Job.DedupKey).
+		WHERE(table.Job.Status.IN(
+			postgres.NewEnumValue(model.JobStatusEnum_NotStarted.String()),
+			postgres.NewEnumValue(model.JobStatusEnum_InProgress.String()),
+		)).
+		DO_NOTHING().
 		RETURNING(table.Job.AllColumns)
 
 	util.DebugCheck(jb.env, statement)
@@ -27,12 +41,115 @@ func (jb *jobRepository) createAllStatement(jobs []model.Job) JobStatement {
 	return JobStatement{db: jb.db, Statement: statement}
 }
 
-func (jb *jobRepository) getNextJobStatement() JobStatement {
-	statement := table.Job.SELECT(table.Job.AllColumns).
+// effectivePriority ages the priority of jobs that are waiting to run by one step for every aging interval (in seconds) they have waited.
+// The priority never improves beyond the highest priority. Aging is disabled when the interval is not positive
+func effectivePriority(aging int) postgres.IntegerExpression {
+	if aging <= 0 {
+		return table.Job.Priority
+	}
+
+	return postgres.IntExp(postgres.Raw(
+		"CASE WHEN job.status = 'not_started' "+
+			"THEN GREATEST(job.priority - FLOOR(GREATEST(EXTRACT(EPOCH FROM (LOCALTIMESTAMP - job.run_after)), 0) / #aging)::integer, #highest) "+
+			"ELSE job.priority END",
+		postgres.RawArgs{
+			"#aging":   aging,
+			"#highest": dto.JobPriority_Highest,
+		},
+	))
+}
+
+// getAllStatement selects a page of jobs and counts all of the jobs that match the search.
+// The effective priority is aliased to the field of models.JobOverviewModel as it is not a column of the job table
+func (jb *jobRepository) getAllStatement(m dto.JobSearchDTO) (postgres.SelectStatement, postgres.SelectStatement) {
+	statement := table.Job.SELECT(
+		table.Job.AllColumns,
+		effectivePriority(jb.env.JobPriorityAging).AS("job_overview_model.effective_priority"),
+	).
+		FROM(table.Job).
+		ORDER_BY(m.OrderBy.ToColumn().DESC()).
+		LIMIT(int64(m.Limit)).
+		OFFSET(int64(m.Skip))
+
+	countStatement := table.Job.SELECT(postgres.COUNT(table.Job.ID).AS("total")).FROM(table.Job)
+
+	var whereExpression postgres.BoolExpression
+	if m.Parent == nil {
+		whereExpression = table.Job.Parent.IS_NULL()
+	} else {
+		id, _ := uuid.Parse(*m.Parent)
+		whereExpression = table.Job.Parent.EQ(postgres.UUID(id))
+	}
+
+	statusExpressions := make([]postgres.Expression, len(m.Statuses))
+	for i, s := range m.Statuses {
+		statusExpressions[i] = postgres.NewEnumValue(string(s))
+	}
+	if len(statusExpressions) > 0 {
+		whereExpression = whereExpression.AND(table.Job.Status.IN(statusExpressions...))
+	}
+
+	jobTypeExpression := make([]postgres.Expression, len(m.JobTypes))
+	for i, t := range m.JobTypes {
+		jobTypeExpression[i] = postgres.NewEnumValue(string(t))
+	}
+	if len(jobTypeExpression) > 0 {
+		whereExpression = whereExpression.AND(table.Job.JobType.IN(jobTypeExpression...))
+	}
+
+	statement = statement.WHERE(whereExpression)
+	countStatement = countStatement.WHERE(whereExpression)
+
+	util.DebugCheck(jb.env, statement)
+	util.DebugCheck(jb.env, countStatement)
+
+	return statement, countStatement
+}
+
+func (jb *jobRepository) getNextJobStatement(excludedTypes []model.JobTypeEnum, worker string) JobStatement {
+	notStarted := table.Job.Status.EQ(postgres.NewEnumValue(string(model.JobStatusEnum_NotStarted)))
+
+	whereExpression := notStarted
+	if len(excludedTypes) > 0 {
+		excludedExpressions := make([]postgres.Expression, len(excludedTypes))
+		for i, t := range excludedTypes {
+			excludedExpressions[i] = postgres.NewEnumValue(string(t))
+		}
+		whereExpression = whereExpression.AND(table.Job.JobType.NOT_IN(excludedExpressions...))
+	}
+
+	nextJob := table.Job.SELECT(table.Job.ID).
 		FROM(table.Job).
-		WHERE(table.Job.Status.EQ(postgres.NewEnumValue(string(model.JobStatusEnum_NotStarted)))).
-		ORDER_BY(table.Job.Priority.ASC(), table.Job.Created.ASC()).
-		LIMIT(1)
+		WHERE(whereExpression.AND(table.Job.RunAfter.LT_EQ(postgres.LOCALTIMESTAMP()))).
+		ORDER_BY(effectivePriority(jb.env.JobPriorityAging).ASC(), table.Job.Created.ASC()).
+		LIMIT(1).
+		FOR(postgres.UPDATE().SKIP_LOCKED())
+
+	now := postgres.TimestampT(time.Now())
+	statement := table.Job.UPDATE(
+		table.Job.Status,
+		table.Job.Modified,
+		table.Job.Attempts,
+		table.Job.Started,
+		table.Job.ProgressProcessed,
+		table.Job.ProgressTotal,
+		table.Job.ProgressMessage,
+		table.Job.Worker,
+		table.Job.Heartbeat,
+	).
+		SET(
+			postgres.NewEnumValue(string(model.JobStatusEnum_InProgress)),
+			now,
+			table.Job.Attempts.ADD(postgres.Int(1)),
+			now,
+			postgres.Int(0),
+			postgres.Int(0),
+			postgres.NULL,
+			postgres.String(worker),
+			now,
+		).
+		WHERE(table.Job.ID.IN(nextJob).AND(notStarted)).
+		RETURNING(table.Job.AllColumns)
 
 	util.DebugCheck(jb.env, statement)
 
@@ -42,7 +159,139 @@ func (jb *jobRepository) getNextJobStatement() JobStatement {
 func (jb *jobRepository) updateJobStatusStatement(model *model.Job) JobStatement {
 	statement := table.Job.UPDATE(table.Job.Modified, table.Job.Status, table.Job.Outcome).
 		MODEL(model).
-		WHERE(table.Job.ID.EQ(postgres.UUID(model.ID)))
+		WHERE(table.Job.ID.EQ(postgres.UUID(model.ID)).AND(inProgress))
+
+	util.DebugCheck(jb.env, statement)
+
+	return JobStatement{statement, jb.db, jb.ctx}
+}
+
+func (jb *jobRepository) cancelStatement(whereExpression postgres.BoolExpression) JobStatement {
+	outcome := cancelledOutcome
+	statement := table.Job.UPDATE(table.Job.Status, table.Job.Modified, table.Job.Outcome).
+		MODEL(model.Job{
+			Status:   model.JobStatusEnum_Cancelled,
+			Modified: time.Now(),
+			Outcome:  &outcome,
+		}).
+		WHERE(whereExpression.AND(table.Job.Status.IN(
+			postgres.NewEnumValue(string(model.JobStatusEnum_NotStarted)),
+			postgres.NewEnumValue(string(model.JobStatusEnum_InProgress)),
+		))).
+		RETURNING(table.Job.AllColumns)
+
+	util.DebugCheck(jb.env, statement)
+
+	return JobStatement{statement, jb.db, jb.ctx}
+}
+
+func (jb *jobRepository) retryStatement(job *model.Job, delay time.Duration) JobStatement {
+	outcome := ""
+	if job.Outcome != nil {
+		outcome = *job.Outcome
+	}
+
+	statement := table.Job.UPDATE(table.Job.Status, table.Job.Modified, table.Job.Outcome, table.Job.RunAfter).
+		SET(
+			postgres.NewEnumValue(string(model.JobStatusEnum_NotStarted)),
+			postgres.TimestampT(job.Modified),
+			postgres.String(outcome),
+			postgres.LOCALTIMESTAMP().ADD(postgres.INTERVALd(delay)),
+		).
+		WHERE(table.Job.ID.EQ(postgres.UUID(job.ID)).AND(inProgress)).
+		RETURNING(table.Job.RunAfter)
+
+	util.DebugCheck(jb.env, statement)
+
+	return JobStatement{statement, jb.db, jb.ctx}
+}
+
+func (jb *jobRepository) updateProgressStatement(job *model.Job) JobStatement {
+	statement := table.Job.UPDATE(table.Job.ProgressProcessed, table.Job.ProgressTotal, table.Job.ProgressMessage).
+		MODEL(job).
+		WHERE(table.Job.ID.EQ(postgres.UUID(job.ID)))
+
+	util.DebugCheck(jb.env, statement)
+
+	return JobStatement{statement, jb.db, jb.ctx}
+}
+
+func (jb *jobRepository) descendantStatusCountsStatement(id uuid.UUID) JobStatement {
+	descendants := postgres.CTE("descendants")
+	descendantId := table.Job.ID.From(descendants)
+	descendantStatus := table.Job.Status.From(descendants)
+
+	statement := postgres.WITH_RECURSIVE(
+		descendants.AS(
+			table.Job.SELECT(table.Job.ID, table.Job.Status).
+				FROM(table.Job).
+				WHERE(table.Job.Parent.EQ(postgres.UUID(id))).
+				UNION_ALL(
+					table.Job.SELECT(table.Job.ID, table.Job.Status).
+						FROM(table.Job.INNER_JOIN(descendants, table.Job.Parent.EQ(descendantId))),
+				),
+		),
+	)(
+		postgres.SELECT(
+			descendantStatus.AS("status"),
+			postgres.COUNT(postgres.STAR).AS("count"),
+		).
+			FROM(descendants).
+			GROUP_BY(descendantStatus),
+	)
+
+	util.DebugCheck(jb.env, statement)
+
+	return JobStatement{statement, jb.db, jb.ctx}
+}
+
+// interruptedExpression matches in progress jobs that are no longer being worked on.
+// Jobs of the given worker are matched regardless of their heartbeat
+func interruptedExpression(worker string, expiredBefore time.Time) postgres.BoolExpression {
+	interrupted := table.Job.Heartbeat.IS_NULL().
+		OR(table.Job.Heartbeat.LT(postgres.TimestampT(expiredBefore)))
+	if worker != "" {
+		interrupted = interrupted.OR(table.Job.Worker.EQ(postgres.String(worker)))
+	}
+
+	return table.Job.Status.EQ(postgres.NewEnumValue(string(model.JobStatusEnum_InProgress))).
+		AND(interrupted)
+}
+
+func (jb *jobRepository) resumeInprogressStatement(jobTypes []model.JobTypeEnum, worker string, expiredBefore time.Time) JobStatement {
+	jobTypeExpressions := make([]postgres.Expression, len(jobTypes))
+	for i, t := range jobTypes {
+		jobTypeExpressions[i] = postgres.NewEnumValue(string(t))
+	}
+
+	statement := table.Job.UPDATE(table.Job.Status, table.Job.Modified, table.Job.Attempts, table.Job.Worker, table.Job.Heartbeat).
+		SET(
+			postgres.NewEnumValue(string(model.JobStatusEnum_NotStarted)),
+			postgres.TimestampT(time.Now()),
+			postgres.GREATEST(table.Job.Attempts.SUB(postgres.Int(1)), postgres.Int(0)),
+			postgres.NULL,
+			postgres.NULL,
+		).
+		WHERE(interruptedExpression(worker, expiredBefore).
+			AND(table.Job.JobType.IN(jobTypeExpressions...))).
+		RETURNING(table.Job.AllColumns)
+
+	util.DebugCheck(jb.env, statement)
+
+	return JobStatement{statement, jb.db, jb.ctx}
+}
+
+func (jb *jobRepository) heartbeatStatement(ids []uuid.UUID, worker string) JobStatement {
+	idExpressions := make([]postgres.Expression, len(ids))
+	for i, id := range ids {
+		idExpressions[i] = postgres.UUID(id)
+	}
+
+	statement := table.Job.UPDATE(table.Job.Heartbeat).
+		SET(postgres.TimestampT(time.Now())).
+		WHERE(table.Job.ID.IN(idExpressions...).
+			AND(table.Job.Worker.EQ(postgres.String(worker)))).
+		RETURNING(table.Job.ID)
 
 	util.DebugCheck(jb.env, statement)
 
@@ -0,0 +1,48 @@
+package jobRepository
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/environment"
+)
+
+var jr = jobRepository{
+	env: &environment.EnvironmentVariables{DebugSql: false, JobPriorityAging: 60},
+}
+
+func Test_GetAllStatement_ShouldMapTheEffectivePriorityToTheJobOverviewModel(t *testing.T) {
+	statement, _ := jr.getAllStatement(dto.JobSearchDTO{OrderBy: dto.JobOrdinal_Created})
+	sql, _ := statement.Sql()
+
+	// the query result mapping only sets fields of a named struct that are prefixed with the name of the struct
+	expected := `AS "job_overview_model.effective_priority"`
+	if !strings.Contains(sql, expected) {
+		t.Errorf("Expected %v in %v", expected, sql)
+	}
+}
+
+func Test_UpdateJobStatusStatement_ShouldOnlyUpdateJobsInProgress(t *testing.T) {
+	job := model.Job{ID: uuid.New(), Status: model.JobStatusEnum_Completed}
+	actual := jr.updateJobStatusStatement(&job).DebugSql()
+
+	expected := fmt.Sprintf("WHERE (job.id = '%v') AND (job.status = 'in_progress');", job.ID)
+	if !strings.Contains(actual, expected) {
+		t.Errorf("Expected %v in %v", expected, actual)
+	}
+}
+
+func Test_RetryStatement_ShouldOnlyRetryJobsInProgress(t *testing.T) {
+	job := model.Job{ID: uuid.New()}
+	actual := jr.retryStatement(&job, time.Minute).DebugSql()
+
+	expected := fmt.Sprintf("WHERE (job.id = '%v') AND (job.status = 'in_progress')", job.ID)
+	if !strings.Contains(actual, expected) {
+		t.Errorf("Expected %v in %v", expected, actual)
+	}
+}
@@ -0,0 +1,123 @@
+package jobLogRepository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/environment"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/repository/util"
+)
+
+var jobLog = table.JobLog
+
+type JobLogRepository interface {
+	CreateAll(logs []model.JobLog) error
+	GetByJobId(jobId uuid.UUID, m dto.JobLogSearchDTO) (*dto.PageDTO[model.JobLog], error)
+}
+
+type jobLogRepository struct {
+	env *environment.EnvironmentVariables
+	db  *sql.DB
+	ctx context.Context
+}
+
+var jobLogRepositoryInstance *jobLogRepository
+
+func New(env *environment.EnvironmentVariables, db *sql.DB, context context.Context) JobLogRepository {
+	if jobLogRepositoryInstance != nil {
+		return jobLogRepositoryInstance
+	}
+
+	jobLogRepositoryInstance = &jobLogRepository{
+		env: env,
+		db:  db,
+		ctx: context,
+	}
+
+	return jobLogRepositoryInstance
+}
+
+// CreateAll implements JobLogRepository.
+func (r *jobLogRepository) CreateAll(logs []model.JobLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	statement := jobLog.INSERT(jobLog.JobID, jobLog.Level, jobLog.Message, jobLog.Created).
+		MODELS(logs)
+
+	util.DebugCheck(r.env, statement)
+
+	if _, err := statement.ExecContext(r.ctx, r.db); err != nil {
+		return errs.BuildError(err, "could not create job logs")
+	}
+
+	return nil
+}
+
+// GetByJobId implements JobLogRepository.
+// The newest logs are returned first unless asc is set
+func (r *jobLogRepository) GetByJobId(jobId uuid.UUID, m dto.JobLogSearchDTO) (*dto.PageDTO[model.JobLog], error) {
+	if m.Limit == 0 {
+		m.Limit = 100
+	}
+
+	whereExpression := jobLog.JobID.EQ(postgres.UUID(jobId))
+
+	levelExpressions := make([]postgres.Expression, len(m.Levels))
+	for i, l := range m.Levels {
+		levelExpressions[i] = postgres.NewEnumValue(l.String())
+	}
+	if len(levelExpressions) > 0 {
+		whereExpression = whereExpression.AND(jobLog.Level.IN(levelExpressions...))
+	}
+
+	orderBy := jobLog.Created.DESC()
+	if m.Asc {
+		orderBy = jobLog.Created.ASC()
+	}
+
+	statement := jobLog.SELECT(jobLog.AllColumns).
+		FROM(jobLog).
+		WHERE(whereExpression).
+		ORDER_BY(orderBy).
+		LIMIT(int64(m.Limit)).
+		OFFSET(int64(m.Skip))
+
+	countStatement := jobLog.SELECT(postgres.COUNT(jobLog.ID).AS("total")).
+		FROM(jobLog).
+		WHERE(whereExpression)
+
+	util.DebugCheck(r.env, statement)
+	util.DebugCheck(r.env, countStatement)
+
+	var totalStruct struct {
+		Total int
+	}
+	if err := countStatement.QueryContext(r.ctx, r.db, &totalStruct); err != nil {
+		return nil, errs.BuildError(err, "could not count logs of job %v", jobId.String())
+	}
+
+	var logsStruct []struct{ model.JobLog }
+	if err := statement.QueryContext(r.ctx, r.db, &logsStruct); err != nil {
+		return nil, errs.BuildError(err, "could not get logs of job %v", jobId.String())
+	}
+
+	logs := make([]model.JobLog, len(logsStruct))
+	for i, l := range logsStruct {
+		logs[i] = l.JobLog
+	}
+
+	return &dto.PageDTO[model.JobLog]{
+		Total: totalStruct.Total,
+		Limit: m.Limit,
+		Skip:  m.Skip,
+		Data:  logs,
+	}, nil
+}
@@ -0,0 +1,203 @@
+package jobScheduleRepository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/environment"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/repository/util"
+)
+
+var jobSchedule = table.JobSchedule
+
+type JobScheduleRepository interface {
+	Create(m model.JobSchedule) (*model.JobSchedule, error)
+	GetAll() ([]model.JobSchedule, error)
+	GetById(id uuid.UUID) (*model.JobSchedule, error)
+	Update(m model.JobSchedule, columns postgres.ColumnList) (*model.JobSchedule, error)
+	Delete(id uuid.UUID) error
+	GetDue(now time.Time) ([]model.JobSchedule, error)
+	ClaimRun(m model.JobSchedule, lastRun, nextRun time.Time) (bool, error)
+}
+
+type jobScheduleRepository struct {
+	env *environment.EnvironmentVariables
+	db  *sql.DB
+	ctx context.Context
+}
+
+var jobScheduleRepositoryInstance *jobScheduleRepository
+
+func New(env *environment.EnvironmentVariables, db *sql.DB, context context.Context) JobScheduleRepository {
+	if jobScheduleRepositoryInstance != nil {
+		return jobScheduleRepositoryInstance
+	}
+
+	jobScheduleRepositoryInstance = &jobScheduleRepository{
+		env: env,
+		db:  db,
+		ctx: context,
+	}
+
+	return jobScheduleRepositoryInstance
+}
+
+// Create implements JobScheduleRepository.
+func (r *jobScheduleRepository) Create(m model.JobSchedule) (*model.JobSchedule, error) {
+	statement := jobSchedule.INSERT(
+		jobSchedule.Name,
+		jobSchedule.Cron,
+		jobSchedule.JobType,
+		jobSchedule.Data,
+		jobSchedule.Priority,
+		jobSchedule.Enabled,
+		jobSchedule.NextRun,
+	).
+		MODEL(m).
+		RETURNING(jobSchedule.AllColumns)
+
+	util.DebugCheck(r.env, statement)
+
+	var created struct{ model.JobSchedule }
+	if err := statement.QueryContext(r.ctx, r.db, &created); err != nil {
+		return nil, errs.BuildError(err, "could not create job schedule")
+	}
+
+	return &created.JobSchedule, nil
+}
+
+// GetAll implements JobScheduleRepository.
+func (r *jobScheduleRepository) GetAll() ([]model.JobSchedule, error) {
+	statement := jobSchedule.SELECT(jobSchedule.AllColumns).
+		FROM(jobSchedule).
+		ORDER_BY(jobSchedule.Name.ASC())
+
+	util.DebugCheck(r.env, statement)
+
+	var schedules []model.JobSchedule
+	if err := statement.QueryContext(r.ctx, r.db, &schedules); err != nil {
+		return nil, errs.BuildError(err, "could not get job schedules")
+	}
+
+	if schedules == nil {
+		schedules = []model.JobSchedule{}
+	}
+
+	return schedules, nil
+}
+
+// GetById implements JobScheduleRepository.
+func (r *jobScheduleRepository) GetById(id uuid.UUID) (*model.JobSchedule, error) {
+	statement := jobSchedule.SELECT(jobSchedule.AllColumns).
+		FROM(jobSchedule).
+		WHERE(jobSchedule.ID.EQ(postgres.UUID(id)))
+
+	util.DebugCheck(r.env, statement)
+
+	var schedules []model.JobSchedule
+	if err := statement.QueryContext(r.ctx, r.db, &schedules); err != nil {
+		return nil, errs.BuildError(err, "could not get job schedule by id %v", id.String())
+	}
+
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	return &schedules[0], nil
+}
+
+// Update implements JobScheduleRepository.
+func (r *jobScheduleRepository) Update(m model.JobSchedule, columns postgres.ColumnList) (*model.JobSchedule, error) {
+	m.Modified = time.Now()
+	columns = append(columns, jobSchedule.Modified)
+
+	statement := jobSchedule.UPDATE(columns).
+		MODEL(m).
+		WHERE(jobSchedule.ID.EQ(postgres.UUID(m.ID))).
+		RETURNING(jobSchedule.AllColumns)
+
+	util.DebugCheck(r.env, statement)
+
+	var updated struct{ model.JobSchedule }
+	if err := statement.QueryContext(r.ctx, r.db, &updated); err != nil {
+		return nil, errs.BuildError(err, "could not update job schedule %v", m.ID.String())
+	}
+
+	return &updated.JobSchedule, nil
+}
+
+// Delete implements JobScheduleRepository.
+func (r *jobScheduleRepository) Delete(id uuid.UUID) error {
+	statement := jobSchedule.DELETE().
+		WHERE(jobSchedule.ID.EQ(postgres.UUID(id)))
+
+	util.DebugCheck(r.env, statement)
+
+	if _, err := statement.ExecContext(r.ctx, r.db); err != nil {
+		return errs.BuildError(err, "could not delete job schedule %v", id.String())
+	}
+
+	return nil
+}
+
+// GetDue implements JobScheduleRepository.
+// Times are stored in UTC
+func (r *jobScheduleRepository) GetDue(now time.Time) ([]model.JobSchedule, error) {
+	statement := jobSchedule.SELECT(jobSchedule.AllColumns).
+		FROM(jobSchedule).
+		WHERE(jobSchedule.Enabled.IS_TRUE().
+			AND(jobSchedule.NextRun.IS_NOT_NULL()).
+			AND(jobSchedule.NextRun.LT_EQ(postgres.TimestampT(now.UTC()))))
+
+	util.DebugCheck(r.env, statement)
+
+	var schedules []model.JobSchedule
+	if err := statement.QueryContext(r.ctx, r.db, &schedules); err != nil {
+		return nil, errs.BuildError(err, "could not get due job schedules")
+	}
+
+	return schedules, nil
+}
+
+// ClaimRun implements JobScheduleRepository.
+// The run is only claimed if no one else has moved the next run of the schedule in the mean time
+func (r *jobScheduleRepository) ClaimRun(m model.JobSchedule, lastRun, nextRun time.Time) (bool, error) {
+	whereExpression := jobSchedule.ID.EQ(postgres.UUID(m.ID))
+	if m.NextRun != nil {
+		whereExpression = whereExpression.AND(jobSchedule.NextRun.EQ(postgres.TimestampT(*m.NextRun)))
+	}
+
+	lastRun = lastRun.UTC()
+	var next *time.Time
+	if !nextRun.IsZero() {
+		n := nextRun.UTC()
+		next = &n
+	}
+
+	statement := jobSchedule.UPDATE(jobSchedule.LastRun, jobSchedule.NextRun).
+		MODEL(model.JobSchedule{
+			LastRun: &lastRun,
+			NextRun: next,
+		}).
+		WHERE(whereExpression)
+
+	util.DebugCheck(r.env, statement)
+
+	result, err := statement.ExecContext(r.ctx, r.db)
+	if err != nil {
+		return false, errs.BuildError(err, "could not claim run for job schedule %v", m.ID.String())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errs.BuildError(err, "could not get affected rows for job schedule %v", m.ID.String())
+	}
+
+	return affected == 1, nil
+}
@@ -23,7 +23,7 @@ type LibraryRepository interface {
 	GetAll() ([]model.Library, error)
 	GetById(uuid.UUID) (*model.Library, error)
 	GetMedia(id, userId uuid.UUID, search dto.MediaSearchDTO) (*dto.PageDTO[models.MediaOverviewModel], error)
-	Update(m model.Library) (*model.Library, error)
+	Update(m model.Library, columns postgres.ColumnList) (*model.Library, error)
 }
 
 type libraryRepository struct {
@@ -33,9 +33,9 @@ type libraryRepository struct {
 }
 
 // Update implements LibraryRepository.
-func (ls *libraryRepository) Update(m model.Library) (*model.Library, error) {
+func (ls *libraryRepository) Update(m model.Library, columns postgres.ColumnList) (*model.Library, error) {
 	m.Modified = time.Now()
-	statement := table.Library.UPDATE(table.Library.Modified, table.Library.Name).
+	statement := table.Library.UPDATE(append(postgres.ColumnList{table.Library.Modified}, columns...)).
 		MODEL(m).
 		WHERE(table.Library.ID.EQ(postgres.UUID(m.ID))).
 		RETURNING(table.Library.AllColumns)
@@ -44,7 +44,7 @@ func (ls *libraryRepository) Update(m model.Library) (*model.Library, error) {
 
 	var updatedModel model.Library
 	if err := statement.QueryContext(ls.ctx, ls.db, &updatedModel); err != nil {
-		return nil, errs.BuildError(err, "could not update library name")
+		return nil, errs.BuildError(err, "could not update library")
 	}
 
 	return &updatedModel, nil
@@ -50,7 +50,7 @@ func (ls *libraryRepository) getLibrariesStatement() *LibraryStatement {
 }
 
 func (ls *libraryRepository) getById(id uuid.UUID) *LibraryStatement {
-	statement := table.Library.SELECT(table.Library.ID, table.Library.Name).
+	statement := table.Library.SELECT(table.Library.AllColumns).
 		FROM(table.Library).
 		WHERE(table.Library.ID.EQ(postgres.UUID(id)))
 
@@ -36,7 +36,7 @@ func Test_GetById(t *testing.T) {
 	statment := lr.getById(id)
 	sql := statment.Sql()
 
-	expectedSql := "\nSELECT library.id AS \"library.id\",\n     library.name AS \"library.name\"\nFROM public.library\nWHERE library.id = $1;\n"
+	expectedSql := "\nSELECT library.id AS \"library.id\",\n     library.name AS \"library.name\",\n     library.library_type AS \"library.library_type\",\n     library.created AS \"library.created\",\n     library.modified AS \"library.modified\",\n     library.ghost_id AS \"library.ghost_id\",\n     library.scan_settings AS \"library.scan_settings\"\nFROM public.library\nWHERE library.id = $1;\n"
 	if sql != expectedSql {
 		t.Errorf("Expected %v but got %v", expectedSql, sql)
 	}
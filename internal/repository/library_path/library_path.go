@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
 	"github.com/google/uuid"
@@ -34,6 +35,8 @@ type LibraryPathRepository interface {
 	GetById(id uuid.UUID) (*model.LibraryPath, error)
 	GetByLibraryId(libraryId uuid.UUID) ([]model.LibraryPath, error)
 	GetContainingPath(path string) ([]model.LibraryPath, error)
+	GetWatched() ([]model.LibraryPath, error)
+	Update(m model.LibraryPath) (*model.LibraryPath, error)
 }
 
 func (i *libraryPathRepository) GetContainingPath(path string) ([]model.LibraryPath, error) {
@@ -112,3 +115,29 @@ func (lps *libraryPathRepository) GetById(id uuid.UUID) (*model.LibraryPath, err
 
 	return &libraryPaths[len(libraryPaths)-1].LibraryPath, nil
 }
+
+// GetWatched returns the library paths that have a watch mode other than off
+func (lps *libraryPathRepository) GetWatched() ([]model.LibraryPath, error) {
+	var libraryPaths []struct{ model.LibraryPath }
+	if err := lps.getWatchedStatement().Query(&libraryPaths); err != nil {
+		return nil, errs.BuildError(err, "could not get watched library paths")
+	}
+
+	libPathModels := []model.LibraryPath{}
+	for _, l := range libraryPaths {
+		libPathModels = append(libPathModels, l.LibraryPath)
+	}
+
+	return libPathModels, nil
+}
+
+func (lps *libraryPathRepository) Update(m model.LibraryPath) (*model.LibraryPath, error) {
+	m.Modified = time.Now()
+
+	var updatedModel struct{ model.LibraryPath }
+	if err := lps.updateStatement(m).Query(&updatedModel); err != nil {
+		return nil, errs.BuildError(err, "could not update library path: %v", m.ID)
+	}
+
+	return &updatedModel.LibraryPath, nil
+}
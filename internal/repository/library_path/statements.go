@@ -51,3 +51,24 @@ func (lps *libraryPathRepository) getByIdStatement(id uuid.UUID) LibraryPathStat
 
 	return LibraryPathStatement{statement, lps.db, lps.ctx}
 }
+
+func (lps *libraryPathRepository) getWatchedStatement() LibraryPathStatement {
+	statement := table.LibraryPath.SELECT(table.LibraryPath.AllColumns).
+		FROM(table.LibraryPath).
+		WHERE(table.LibraryPath.Watch.NOT_EQ(postgres.NewEnumValue(model.WatchModeEnum_Off.String())))
+
+	util.DebugCheck(lps.env, statement)
+
+	return LibraryPathStatement{statement, lps.db, lps.ctx}
+}
+
+func (lps *libraryPathRepository) updateStatement(m model.LibraryPath) LibraryPathStatement {
+	statement := table.LibraryPath.UPDATE(table.LibraryPath.Modified, table.LibraryPath.Watch).
+		MODEL(m).
+		WHERE(table.LibraryPath.ID.EQ(postgres.UUID(m.ID))).
+		RETURNING(table.LibraryPath.AllColumns)
+
+	util.DebugCheck(lps.env, statement)
+
+	return LibraryPathStatement{statement, lps.db, lps.ctx}
+}
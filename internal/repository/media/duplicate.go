@@ -0,0 +1,198 @@
+package mediaRepository
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/models"
+	"github.com/slugger7/exorcist/internal/repository/util"
+)
+
+// duplicateCandidates is the media that can have duplicates
+func duplicateCandidates(m *table.MediaTable) postgres.BoolExpression {
+	return m.MediaType.EQ(postgres.NewEnumValue(model.MediaTypeEnum_Primary.String())).
+		AND(m.Exists.IS_TRUE()).
+		AND(m.Deleted.IS_FALSE())
+}
+
+func (r *mediaRepository) getDuplicatesStatement(by dto.DuplicateKey) postgres.SelectStatement {
+	duplicate := table.Media.AS("duplicate")
+	hasDuplicates := postgres.COUNT(postgres.STAR).GT(postgres.Int(1))
+
+	// media with the same size and runtime has to have the same size so the runtime is compared when grouping
+	where := duplicateCandidates(media).
+		AND(media.Size.IN(
+			duplicate.SELECT(duplicate.Size).
+				FROM(duplicate).
+				WHERE(duplicateCandidates(duplicate)).
+				GROUP_BY(duplicate.Size).
+				HAVING(hasDuplicates),
+		))
+	switch by {
+	case dto.DuplicateKey_SizeRuntime:
+		// the size is compared above
+	case dto.DuplicateKey_PartialChecksum:
+		where = duplicateCandidates(media).
+			AND(media.PartialChecksum.IN(
+				duplicate.SELECT(duplicate.PartialChecksum).
+					FROM(duplicate).
+					WHERE(duplicateCandidates(duplicate).AND(duplicate.PartialChecksum.IS_NOT_NULL())).
+					GROUP_BY(duplicate.PartialChecksum).
+					HAVING(hasDuplicates),
+			))
+	default:
+		where = duplicateCandidates(media).
+			AND(media.Checksum.IN(
+				duplicate.SELECT(duplicate.Checksum).
+					FROM(duplicate).
+					WHERE(duplicateCandidates(duplicate).AND(duplicate.Checksum.IS_NOT_NULL())).
+					GROUP_BY(duplicate.Checksum).
+					HAVING(hasDuplicates),
+			))
+	}
+
+	return selectDuplicateMedia().
+		WHERE(where).
+		ORDER_BY(media.Checksum, media.PartialChecksum, media.Size, media.Added)
+}
+
+// selectDuplicateMedia selects the media with the details that are needed to choose which duplicate to keep
+func selectDuplicateMedia(projections ...postgres.Projection) postgres.SelectStatement {
+	return media.SELECT(
+		media.AllColumns,
+		append([]postgres.Projection{
+			table.Video.Width,
+			table.Video.Height,
+			table.Video.Runtime,
+			table.Image.Width,
+			table.Image.Height,
+			table.Library.ID,
+			table.Library.Name,
+		}, projections...)...,
+	).
+		FROM(media.
+			INNER_JOIN(table.LibraryPath, table.LibraryPath.ID.EQ(media.LibraryPathID)).
+			INNER_JOIN(table.Library, table.Library.ID.EQ(table.LibraryPath.LibraryID)).
+			LEFT_JOIN(table.Video, table.Video.MediaID.EQ(media.ID)).
+			LEFT_JOIN(table.Image, table.Image.MediaID.EQ(media.ID)),
+		)
+}
+
+func (r *mediaRepository) getFingerprintedStatement() postgres.SelectStatement {
+	return selectDuplicateMedia(table.Video.Fingerprint).
+		WHERE(duplicateCandidates(media).AND(table.Video.Fingerprint.IS_NOT_NULL())).
+		ORDER_BY(table.Video.Runtime, media.Added)
+}
+
+// GetFingerprinted implements MediaRepository.
+func (r *mediaRepository) GetFingerprinted() ([]models.DuplicateMedia, error) {
+	statement := r.getFingerprintedStatement()
+
+	util.DebugCheck(r.env, statement)
+
+	var results []models.DuplicateMedia
+	if err := statement.QueryContext(r.ctx, r.db, &results); err != nil {
+		return nil, errs.BuildError(err, "could not get fingerprinted media")
+	}
+
+	return results, nil
+}
+
+// GetDuplicates implements MediaRepository.
+func (r *mediaRepository) GetDuplicates(by dto.DuplicateKey) ([]models.DuplicateMedia, error) {
+	statement := r.getDuplicatesStatement(by)
+
+	util.DebugCheck(r.env, statement)
+
+	var results []models.DuplicateMedia
+	if err := statement.QueryContext(r.ctx, r.db, &results); err != nil {
+		return nil, errs.BuildError(err, "could not get duplicate media by %v", by)
+	}
+
+	return results, nil
+}
+
+func (r *mediaRepository) mergeStatements(keepId, duplicateId uuid.UUID) []postgres.Statement {
+	keep, duplicate := postgres.UUID(keepId), postgres.UUID(duplicateId)
+	// a literal in the select list is text and is not cast to the uuid column that it is inserted into
+	keepProjection := postgres.CAST(keep).AS("uuid")
+
+	mediaTag := table.MediaTag
+	duplicateTag := table.MediaTag.AS("duplicate_tag")
+	tags := mediaTag.INSERT(mediaTag.MediaID, mediaTag.TagID).
+		QUERY(duplicateTag.SELECT(keepProjection, duplicateTag.TagID).
+			FROM(duplicateTag).
+			WHERE(duplicateTag.MediaID.EQ(duplicate).
+				AND(duplicateTag.TagID.NOT_IN(
+					mediaTag.SELECT(mediaTag.TagID).FROM(mediaTag).WHERE(mediaTag.MediaID.EQ(keep)),
+				))))
+
+	mediaPerson := table.MediaPerson
+	duplicatePerson := table.MediaPerson.AS("duplicate_person")
+	people := mediaPerson.INSERT(mediaPerson.MediaID, mediaPerson.PersonID).
+		QUERY(duplicatePerson.SELECT(keepProjection, duplicatePerson.PersonID).
+			FROM(duplicatePerson).
+			WHERE(duplicatePerson.MediaID.EQ(duplicate).
+				AND(duplicatePerson.PersonID.NOT_IN(
+					mediaPerson.SELECT(mediaPerson.PersonID).FROM(mediaPerson).WHERE(mediaPerson.MediaID.EQ(keep)),
+				))))
+
+	favourite := table.FavouriteMedia
+	duplicateFavourite := table.FavouriteMedia.AS("duplicate_favourite")
+	favourites := favourite.INSERT(favourite.MediaID, favourite.UserID).
+		QUERY(duplicateFavourite.SELECT(keepProjection, duplicateFavourite.UserID).
+			FROM(duplicateFavourite).
+			WHERE(duplicateFavourite.MediaID.EQ(duplicate).
+				AND(duplicateFavourite.UserID.NOT_IN(
+					favourite.SELECT(favourite.UserID).FROM(favourite).WHERE(favourite.MediaID.EQ(keep)),
+				))))
+
+	// the furthest progress of a user is kept
+	mediaProgress := table.MediaProgress
+	duplicateProgress := table.MediaProgress.AS("duplicate_progress")
+	progress := mediaProgress.INSERT(mediaProgress.MediaID, mediaProgress.UserID, mediaProgress.Timestamp).
+		QUERY(duplicateProgress.SELECT(keepProjection, duplicateProgress.UserID, duplicateProgress.Timestamp).
+			FROM(duplicateProgress).
+			WHERE(duplicateProgress.MediaID.EQ(duplicate))).
+		ON_CONFLICT(mediaProgress.MediaID, mediaProgress.UserID).
+		DO_UPDATE(postgres.SET(
+			mediaProgress.Timestamp.SET(postgres.FloatExp(postgres.GREATEST(mediaProgress.Timestamp, mediaProgress.EXCLUDED.Timestamp))),
+			mediaProgress.Modified.SET(postgres.LOCALTIMESTAMP()),
+		))
+
+	return []postgres.Statement{tags, people, favourites, progress}
+}
+
+// ResolveDuplicates implements MediaRepository.
+func (r *mediaRepository) ResolveDuplicates(keepId uuid.UUID, duplicateIds []uuid.UUID, deleted []model.Media) error {
+	tx, err := r.db.BeginTx(r.ctx, nil)
+	if err != nil {
+		return errs.BuildError(err, "could not begin transaction to resolve duplicates of %v", keepId)
+	}
+	defer tx.Rollback()
+
+	statements := []postgres.Statement{}
+	for _, id := range duplicateIds {
+		statements = append(statements, r.mergeStatements(keepId, id)...)
+	}
+	for _, m := range deleted {
+		statements = append(statements, r.deleteStatement(m))
+	}
+
+	for _, statement := range statements {
+		util.DebugCheck(r.env, statement)
+
+		if _, err := statement.ExecContext(r.ctx, tx); err != nil {
+			return errs.BuildError(err, "could not resolve duplicates of %v", keepId)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errs.BuildError(err, "could not commit resolving duplicates of %v", keepId)
+	}
+
+	return nil
+}
@@ -23,9 +23,11 @@ var media = table.Media
 type MediaRepository interface {
 	Create([]model.Media) ([]model.Media, error)
 	UpdateExists(model.Media) error
+	// UpdateChecksum stores the full checksum along with its algorithm
 	UpdateChecksum(m models.Media) error
 	GetAll(userId uuid.UUID, search dto.MediaSearchDTO) (*dto.PageDTO[models.MediaOverviewModel], error)
 	GetByLibraryPathId(id uuid.UUID) ([]model.Media, error)
+	GetMissing() ([]model.Media, error)
 	GetByLibraryId(libraryId uuid.UUID, pageRequest *dto.PageRequestDTO, columns postgres.ColumnList) (*dto.PageDTO[model.Media], error)
 	GetById(id uuid.UUID) (*models.Media, error)
 	GetByIdAndUserId(id, userId uuid.UUID) (*models.Media, error)
@@ -36,6 +38,12 @@ type MediaRepository interface {
 	UpsertProgress(prog model.MediaProgress) (*model.MediaProgress, error)
 	Update(m model.Media, columns postgres.ColumnList) (*model.Media, error)
 	RemoveRelation(id, relatedTo uuid.UUID) error
+	GetDuplicates(by dto.DuplicateKey) ([]models.DuplicateMedia, error)
+	// ResolveDuplicates adds the tags, people, favourites and progress of the duplicates to the media that is kept and
+	// deletes the duplicates and their assets like Delete in a single transaction. Files are not removed
+	ResolveDuplicates(keepId uuid.UUID, duplicateIds []uuid.UUID, deleted []model.Media) error
+	// GetFingerprinted fetches the videos that have a fingerprint ordered by runtime
+	GetFingerprinted() ([]models.DuplicateMedia, error)
 }
 
 type mediaRepository struct {
@@ -201,15 +209,18 @@ func (r *mediaRepository) GetAssetsFor(id uuid.UUID) ([]model.Media, error) {
 
 // Delete implements MediaRepository.
 func (r *mediaRepository) Delete(m model.Media) error {
+	_, err := r.deleteStatement(m).ExecContext(r.ctx, r.db)
+
+	return err
+}
+
+// deleteStatement stores the deleted and exists flags of the media
+func (r *mediaRepository) deleteStatement(m model.Media) postgres.UpdateStatement {
 	m.Modified = time.Now()
 
-	updateStatement := media.UPDATE(media.Exists, media.Deleted, media.Modified).
+	return media.UPDATE(media.Exists, media.Deleted, media.Modified).
 		MODEL(m).
 		WHERE(media.ID.EQ(postgres.UUID(m.ID)))
-
-	_, err := updateStatement.ExecContext(r.ctx, r.db)
-
-	return err
 }
 
 var mediaRepositoryInstance *mediaRepository
@@ -240,6 +251,11 @@ func (r *mediaRepository) Create(ms []model.Media) ([]model.Media, error) {
 		media.Title,
 		media.Size,
 		media.MediaType,
+		media.Checksum,
+		media.ChecksumAlgorithm,
+		media.PartialChecksum,
+		media.PartialChecksumAlgorithm,
+		media.FileModified,
 	).
 		MODELS(ms).
 		RETURNING(media.AllColumns)
@@ -280,6 +296,7 @@ func (r *mediaRepository) UpdateChecksum(m models.Media) error {
 	statement := media.UPDATE().
 		SET(
 			media.Checksum.SET(postgres.String(*m.Checksum)),
+			media.ChecksumAlgorithm.SET(postgres.NewEnumValue(m.ChecksumAlgorithm.String())),
 			media.Modified.SET(postgres.TimestampT(m.Media.Modified)),
 		).
 		MODEL(m).
@@ -312,9 +329,10 @@ func (r *mediaRepository) GetAll(userId uuid.UUID, search dto.MediaSearchDTO) (*
 }
 
 func (r *mediaRepository) GetByLibraryPathId(id uuid.UUID) ([]model.Media, error) {
-	statement := media.SELECT(media.Path, media.ID).
+	statement := media.SELECT(media.AllColumns).
 		FROM(media).
 		WHERE(media.LibraryPathID.EQ(postgres.UUID(id)).
+			AND(media.MediaType.EQ(postgres.NewEnumValue(model.MediaTypeEnum_Primary.String()))).
 			AND(media.Exists.IS_TRUE()))
 
 	util.DebugCheck(r.env, statement)
@@ -327,6 +345,24 @@ func (r *mediaRepository) GetByLibraryPathId(id uuid.UUID) ([]model.Media, error
 	return results, nil
 }
 
+// GetMissing returns the primary media of which the file was not found during a scan and that was not deleted
+func (r *mediaRepository) GetMissing() ([]model.Media, error) {
+	statement := media.SELECT(media.AllColumns).
+		FROM(media).
+		WHERE(media.MediaType.EQ(postgres.NewEnumValue(model.MediaTypeEnum_Primary.String())).
+			AND(media.Exists.IS_FALSE()).
+			AND(media.Deleted.IS_FALSE()))
+
+	util.DebugCheck(r.env, statement)
+
+	var results []model.Media
+	if err := statement.QueryContext(r.ctx, r.db, &results); err != nil {
+		return nil, errs.BuildError(err, "could not get missing media")
+	}
+
+	return results, nil
+}
+
 func (r *mediaRepository) GetById(id uuid.UUID) (*models.Media, error) {
 	return r.GetByIdAndUserId(id, uuid.New())
 }
@@ -11,12 +11,14 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/slugger7/exorcist/internal/environment"
 	errs "github.com/slugger7/exorcist/internal/errors"
 	"github.com/slugger7/exorcist/internal/logger"
 	imageRepository "github.com/slugger7/exorcist/internal/repository/image"
 	jobRepository "github.com/slugger7/exorcist/internal/repository/job"
+	jobLogRepository "github.com/slugger7/exorcist/internal/repository/job_log"
+	jobScheduleRepository "github.com/slugger7/exorcist/internal/repository/job_schedule"
 	libraryRepository "github.com/slugger7/exorcist/internal/repository/library"
 	libraryPathRepository "github.com/slugger7/exorcist/internal/repository/library_path"
 	mediaRepository "github.com/slugger7/exorcist/internal/repository/media"
@@ -32,7 +34,11 @@ type Repository interface {
 
 	Close() error
 
+	Listen(channel string) (*pq.Listener, error)
+
 	Job() jobRepository.JobRepository
+	JobLog() jobLogRepository.JobLogRepository
+	JobSchedule() jobScheduleRepository.JobScheduleRepository
 	Library() libraryRepository.LibraryRepository
 	LibraryPath() libraryPathRepository.LibraryPathRepository
 	Video() videoRepository.VideoRepository
@@ -46,9 +52,12 @@ type Repository interface {
 
 type repository struct {
 	db              *sql.DB
+	connStr         string
 	logger          logger.Logger
 	env             *environment.EnvironmentVariables
 	jobRepo         jobRepository.JobRepository
+	jobLogRepo      jobLogRepository.JobLogRepository
+	jobScheduleRepo jobScheduleRepository.JobScheduleRepository
 	libraryRepo     libraryRepository.LibraryRepository
 	libraryPathRepo libraryPathRepository.LibraryPathRepository
 	videoRepo       videoRepository.VideoRepository
@@ -78,9 +87,12 @@ func New(env *environment.EnvironmentVariables, context context.Context) Reposit
 
 		dbInstance = &repository{
 			db:              db,
+			connStr:         psqlconn,
 			env:             env,
 			logger:          logger.New(env),
 			jobRepo:         jobRepository.New(db, env, context),
+			jobLogRepo:      jobLogRepository.New(env, db, context),
+			jobScheduleRepo: jobScheduleRepository.New(env, db, context),
 			libraryRepo:     libraryRepository.New(db, env, context),
 			libraryPathRepo: libraryPathRepository.New(db, env, context),
 			videoRepo:       videoRepository.New(db, env, context),
@@ -107,6 +119,16 @@ func (s *repository) Job() jobRepository.JobRepository {
 	return s.jobRepo
 }
 
+func (s *repository) JobLog() jobLogRepository.JobLogRepository {
+	s.logger.Debug("Getting job log repo")
+	return s.jobLogRepo
+}
+
+func (s *repository) JobSchedule() jobScheduleRepository.JobScheduleRepository {
+	s.logger.Debug("Getting job schedule repo")
+	return s.jobScheduleRepo
+}
+
 func (s *repository) Library() libraryRepository.LibraryRepository {
 	s.logger.Debug("Getting library repo")
 	return s.libraryRepo
@@ -214,6 +236,28 @@ func (s *repository) Close() error {
 	return s.db.Close()
 }
 
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// Listen opens a dedicated connection that receives the notifications sent on the channel.
+// The listener reconnects by itself and sends a nil notification after reconnecting
+func (s *repository) Listen(channel string) (*pq.Listener, error) {
+	listener := pq.NewListener(s.connStr, listenerMinReconnect, listenerMaxReconnect, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			s.logger.Errorf("listener on %v: %v", channel, err.Error())
+		}
+	})
+
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return nil, errs.BuildError(err, "could not listen on channel %v", channel)
+	}
+
+	return listener, nil
+}
+
 func (s *repository) runMigrations() error {
 	driver, err := postgres.WithInstance(s.db, &postgres.Config{})
 	if err != nil {
@@ -27,6 +27,7 @@ type MediaVideoModel struct {
 type VideoRepository interface {
 	GetAll() ([]model.Video, error)
 	Insert(models []model.Video) ([]model.Video, error)
+	UpdateByMediaId(m model.Video, columns postgres.ColumnList) error
 	GetByIdWithMedia(id uuid.UUID) (*MediaVideoModel, error)
 	GetByMediaId(id uuid.UUID) (*MediaVideoModel, error)
 }
@@ -120,6 +121,25 @@ func (r *videoRepository) Insert(models []model.Video) ([]model.Video, error) {
 	return vids, nil
 }
 
+// UpdateByMediaId updates the columns of the video of the media
+func (r *videoRepository) UpdateByMediaId(m model.Video, columns postgres.ColumnList) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	statement := table.Video.UPDATE(columns).
+		MODEL(m).
+		WHERE(table.Video.MediaID.EQ(postgres.UUID(m.MediaID)))
+
+	util.DebugCheck(r.env, statement)
+
+	if _, err := statement.ExecContext(r.ctx, r.db); err != nil {
+		return errs.BuildError(err, "could not update video of media: %v", m.MediaID)
+	}
+
+	return nil
+}
+
 func (r *videoRepository) GetByIdWithMedia(id uuid.UUID) (*MediaVideoModel, error) {
 	video := table.Video
 	media := table.Media
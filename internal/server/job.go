@@ -1,11 +1,14 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/dto"
+	jobService "github.com/slugger7/exorcist/internal/service/job"
 )
 
 // https://medium.com/@abhishekranjandev/building-a-production-grade-websocket-for-notifications-with-golang-and-gin-a-detailed-guide-5b676dcfbd5a
@@ -25,13 +28,34 @@ func (s *server) withJobGetAll(r *gin.RouterGroup, route Route) *server {
 	return s
 }
 
+func (s *server) withJobCancel(r *gin.RouterGroup, route Route) *server {
+	r.DELETE(fmt.Sprintf("%v/:%v", route, idKey), s.cancelJob)
+	return s
+}
+
+func (s *server) withJobCancelChildren(r *gin.RouterGroup, route Route) *server {
+	r.DELETE(fmt.Sprintf("%v/:%v/children", route, idKey), s.cancelChildJobs)
+	return s
+}
+
+func (s *server) withJobGetTree(r *gin.RouterGroup, route Route) *server {
+	r.GET(fmt.Sprintf("%v/:%v/tree", route, idKey), s.getJobTree)
+	return s
+}
+
+func (s *server) withJobGetLogs(r *gin.RouterGroup, route Route) *server {
+	r.GET(fmt.Sprintf("%v/:%v/logs", route, idKey), s.getJobLogs)
+	return s
+}
+
 func (s *server) startJobRunner(c *gin.Context) {
 	s.jobCh <- true
 	c.JSON(http.StatusOK, nil)
 }
 
 const (
-	ErrJobCreate ApiError = "could not create job"
+	ErrJobCreate    ApiError = "could not create job"
+	ErrJobDuplicate ApiError = "a job doing the same work is already pending or running"
 )
 
 func (s *server) CreateJob(c *gin.Context) {
@@ -42,6 +66,11 @@ func (s *server) CreateJob(c *gin.Context) {
 	}
 
 	job, err := s.service.Job().Create(cm)
+	if errors.Is(err, jobService.ErrDuplicateJob) {
+		c.JSON(http.StatusConflict, createError(ErrJobDuplicate))
+		return
+	}
+
 	if err != nil {
 		s.logger.Errorf("could not create job: %v", err.Error())
 		c.JSON(http.StatusInternalServerError, createError(ErrJobCreate))
@@ -73,8 +102,147 @@ func (s *server) getAllJobs(c *gin.Context) {
 
 	jobDtos := make([]dto.JobDTO, len(jobsPage.Data))
 	for i, j := range jobsPage.Data {
-		jobDtos[i] = *(&dto.JobDTO{}).FromModel(j)
+		jobDtos[i] = *(&dto.JobDTO{}).FromOverviewModel(j)
 	}
 
 	c.JSON(http.StatusOK, dto.DataToPage(jobDtos, *jobsPage))
 }
+
+const (
+	ErrCancelJob   ApiError = "could not cancel job"
+	ErrJobFinished ApiError = "job already finished"
+)
+
+func (s *server) cancelJob(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	job, err := s.service.Job().Cancel(id)
+	if errors.Is(err, jobService.ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, createError(ErrJobNotFound))
+		return
+	}
+
+	if errors.Is(err, jobService.ErrJobFinished) {
+		c.JSON(http.StatusConflict, createError(ErrJobFinished))
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf("could not cancel job %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrCancelJob))
+		return
+	}
+
+	s.wsService.JobUpdate(*job)
+
+	c.JSON(http.StatusOK, (&dto.JobDTO{}).FromModel(*job))
+}
+
+const ErrCancelChildJobs ApiError = "could not cancel child jobs"
+
+func (s *server) cancelChildJobs(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	jobs, err := s.service.Job().CancelByParent(id)
+	if errors.Is(err, jobService.ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, createError(ErrJobNotFound))
+		return
+	}
+
+	if errors.Is(err, jobService.ErrJobFinished) {
+		c.JSON(http.StatusConflict, createError(ErrJobFinished))
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf("could not cancel child jobs of %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrCancelChildJobs))
+		return
+	}
+
+	jobDtos := make([]dto.JobDTO, len(jobs))
+	for i, j := range jobs {
+		s.wsService.JobUpdate(j)
+		jobDtos[i] = *(&dto.JobDTO{}).FromModel(j)
+	}
+
+	c.JSON(http.StatusOK, jobDtos)
+}
+
+const ErrGetJobTree ApiError = "could not get job tree"
+
+func (s *server) getJobTree(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	tree, err := s.service.Job().GetTree(id)
+	if errors.Is(err, jobService.ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, createError(ErrJobNotFound))
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf("could not get job tree for %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetJobTree))
+		return
+	}
+
+	c.JSON(http.StatusOK, (&dto.JobTreeDTO{}).FromModel(*tree))
+}
+
+const (
+	ErrGetJobLogs  ApiError = "could not get job logs"
+	ErrJobNotFound ApiError = "job not found"
+)
+
+func (s *server) getJobLogs(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	var logSearch dto.JobLogSearchDTO
+	if err := c.ShouldBindQuery(&logSearch); err != nil {
+		s.logger.Errorf("could not bind query to entity %v", err.Error())
+		c.AbortWithStatus(http.StatusUnprocessableEntity)
+		return
+	}
+
+	job, err := s.repo.Job().GetById(id)
+	if err != nil {
+		s.logger.Errorf("could not get job %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetJobLogs))
+		return
+	}
+
+	if job == nil {
+		c.JSON(http.StatusNotFound, createError(ErrJobNotFound))
+		return
+	}
+
+	logsPage, err := s.repo.JobLog().GetByJobId(id, logSearch)
+	if err != nil {
+		s.logger.Errorf("could not get logs of job %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetJobLogs))
+		return
+	}
+
+	logDtos := make([]dto.JobLogDTO, len(logsPage.Data))
+	for i, l := range logsPage.Data {
+		logDtos[i] = *(&dto.JobLogDTO{}).FromModel(l)
+	}
+
+	c.JSON(http.StatusOK, dto.DataToPage(logDtos, *logsPage))
+}
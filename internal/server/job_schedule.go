@@ -0,0 +1,161 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/dto"
+	jobScheduleService "github.com/slugger7/exorcist/internal/service/job_schedule"
+)
+
+func (s *server) withJobScheduleGetAll(r *gin.RouterGroup, route Route) *server {
+	r.GET(route, s.getAllJobSchedules)
+	return s
+}
+
+func (s *server) withJobScheduleGet(r *gin.RouterGroup, route Route) *server {
+	r.GET(fmt.Sprintf("%v/:%v", route, idKey), s.getJobSchedule)
+	return s
+}
+
+func (s *server) withJobScheduleCreate(r *gin.RouterGroup, route Route) *server {
+	r.POST(route, s.createJobSchedule)
+	return s
+}
+
+func (s *server) withJobSchedulePut(r *gin.RouterGroup, route Route) *server {
+	r.PUT(fmt.Sprintf("%v/:%v", route, idKey), s.putJobSchedule)
+	return s
+}
+
+func (s *server) withJobScheduleDelete(r *gin.RouterGroup, route Route) *server {
+	r.DELETE(fmt.Sprintf("%v/:%v", route, idKey), s.deleteJobSchedule)
+	return s
+}
+
+const ErrGetAllJobSchedules ApiError = "could not get job schedules"
+
+func (s *server) getAllJobSchedules(c *gin.Context) {
+	schedules, err := s.repo.JobSchedule().GetAll()
+	if err != nil {
+		s.logger.Errorf("could not get job schedules: %v", err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetAllJobSchedules))
+		return
+	}
+
+	scheduleDtos := make([]dto.JobScheduleDTO, len(schedules))
+	for i, j := range schedules {
+		scheduleDtos[i] = *(&dto.JobScheduleDTO{}).FromModel(j)
+	}
+
+	c.JSON(http.StatusOK, scheduleDtos)
+}
+
+const (
+	ErrGetJobSchedule      ApiError = "could not get job schedule"
+	ErrJobScheduleNotFound ApiError = "job schedule not found"
+)
+
+func (s *server) getJobSchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	schedule, err := s.repo.JobSchedule().GetById(id)
+	if err != nil {
+		s.logger.Errorf("could not get job schedule %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetJobSchedule))
+		return
+	}
+
+	if schedule == nil {
+		c.JSON(http.StatusNotFound, createError(ErrJobScheduleNotFound))
+		return
+	}
+
+	c.JSON(http.StatusOK, (&dto.JobScheduleDTO{}).FromModel(*schedule))
+}
+
+const ErrCreateJobSchedule ApiError = "could not create job schedule"
+
+func (s *server) createJobSchedule(c *gin.Context) {
+	var cm dto.CreateJobScheduleDTO
+	if err := c.ShouldBindBodyWithJSON(&cm); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err})
+		return
+	}
+
+	schedule, err := s.service.JobSchedule().Create(cm)
+	if err != nil {
+		s.logger.Errorf("could not create job schedule: %v", err.Error())
+		c.JSON(http.StatusBadRequest, createError(ErrCreateJobSchedule))
+		return
+	}
+
+	c.JSON(http.StatusCreated, (&dto.JobScheduleDTO{}).FromModel(*schedule))
+}
+
+const ErrUpdateJobSchedule ApiError = "could not update job schedule"
+
+func (s *server) putJobSchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	var um dto.UpdateJobScheduleDTO
+	if err := c.ShouldBindBodyWithJSON(&um); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err})
+		return
+	}
+
+	schedule, err := s.service.JobSchedule().Update(id, um)
+	if errors.Is(err, jobScheduleService.ErrJobScheduleNotFound) {
+		c.JSON(http.StatusNotFound, createError(ErrJobScheduleNotFound))
+		return
+	}
+
+	if errors.Is(err, jobScheduleService.ErrInvalidJobSchedule) {
+		s.logger.Errorf("could not update job schedule %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusBadRequest, createError(ErrUpdateJobSchedule))
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf("could not update job schedule %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrUpdateJobSchedule))
+		return
+	}
+
+	c.JSON(http.StatusOK, (&dto.JobScheduleDTO{}).FromModel(*schedule))
+}
+
+const ErrDeleteJobSchedule ApiError = "could not delete job schedule"
+
+func (s *server) deleteJobSchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	err = s.service.JobSchedule().Delete(id)
+	if errors.Is(err, jobScheduleService.ErrJobScheduleNotFound) {
+		c.JSON(http.StatusNotFound, createError(ErrJobScheduleNotFound))
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf("could not delete job schedule %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrDeleteJobSchedule))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/assert"
+	jobScheduleService "github.com/slugger7/exorcist/internal/service/job_schedule"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_PutJobSchedule_WithMissingSchedule_ShouldReturnNotFound(t *testing.T) {
+	s := setupServer(t).
+		withJobScheduleService()
+
+	id, _ := uuid.NewRandom()
+	s.mockJobScheduleService.EXPECT().
+		Update(id, gomock.Any()).
+		Return(nil, fmt.Errorf("%w: %v", jobScheduleService.ErrJobScheduleNotFound, id)).
+		Times(1)
+
+	s.server.withJobSchedulePut(&s.engine.RouterGroup, "/")
+	rr := s.withPutRequest(body(`{}`), id.String()).
+		exec()
+
+	assert.StatusCode(t, http.StatusNotFound, rr.Code)
+	assert.Body(t, errBody(ErrJobScheduleNotFound), rr.Body.String())
+}
+
+func Test_PutJobSchedule_WithInvalidSchedule_ShouldReturnBadRequest(t *testing.T) {
+	s := setupServer(t).
+		withJobScheduleService()
+
+	id, _ := uuid.NewRandom()
+	s.mockJobScheduleService.EXPECT().
+		Update(id, gomock.Any()).
+		Return(nil, fmt.Errorf("%w: invalid cron expression", jobScheduleService.ErrInvalidJobSchedule)).
+		Times(1)
+
+	s.server.withJobSchedulePut(&s.engine.RouterGroup, "/")
+	rr := s.withPutRequest(body(`{}`), id.String()).
+		exec()
+
+	assert.StatusCode(t, http.StatusBadRequest, rr.Code)
+}
+
+func Test_PutJobSchedule_ErrFromService(t *testing.T) {
+	s := setupServer(t).
+		withJobScheduleService()
+
+	id, _ := uuid.NewRandom()
+	s.mockJobScheduleService.EXPECT().
+		Update(id, gomock.Any()).
+		Return(nil, fmt.Errorf("some error")).
+		Times(1)
+
+	s.server.withJobSchedulePut(&s.engine.RouterGroup, "/")
+	rr := s.withPutRequest(body(`{}`), id.String()).
+		exec()
+
+	assert.StatusCode(t, http.StatusInternalServerError, rr.Code)
+	assert.Body(t, errBody(ErrUpdateJobSchedule), rr.Body.String())
+}
+
+func Test_DeleteJobSchedule_WithMissingSchedule_ShouldReturnNotFound(t *testing.T) {
+	s := setupServer(t).
+		withJobScheduleService()
+
+	id, _ := uuid.NewRandom()
+	s.mockJobScheduleService.EXPECT().
+		Delete(id).
+		Return(fmt.Errorf("%w: %v", jobScheduleService.ErrJobScheduleNotFound, id)).
+		Times(1)
+
+	s.server.withJobScheduleDelete(&s.engine.RouterGroup, "/")
+	rr := s.withDeleteRequest(id.String()).
+		exec()
+
+	assert.StatusCode(t, http.StatusNotFound, rr.Code)
+	assert.Body(t, errBody(ErrJobScheduleNotFound), rr.Body.String())
+}
@@ -1,13 +1,17 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-jet/jet/v2/postgres"
 	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
 	"github.com/slugger7/exorcist/internal/dto"
+	filesystem "github.com/slugger7/exorcist/internal/media"
 )
 
 func (s *server) withLibraryPost(r *gin.RouterGroup, route Route) *server {
@@ -38,6 +42,7 @@ func (s *server) withLibraryPut(r *gin.RouterGroup, route Route) *server {
 const (
 	ErrLibraryPathsForLibrary ApiError = "could not get library paths for library %v"
 	ErrIdParse                ApiError = "could not parse id: %v"
+	ErrLibraryExclude         ApiError = "invalid exclude pattern: %v"
 )
 
 func (s *server) putLibrary(c *gin.Context) {
@@ -57,8 +62,29 @@ func (s *server) putLibrary(c *gin.Context) {
 		ID:   id,
 		Name: updateDto.Name,
 	}
+	columns := postgres.ColumnList{}
+	if updateDto.Name != "" {
+		columns = append(columns, table.Library.Name)
+	}
+
+	if updateDto.ScanSettings != nil {
+		if err := filesystem.ValidateExcludePatterns(updateDto.ScanSettings.Exclude); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, createError(fmt.Sprintf(ErrLibraryExclude, err.Error())))
+			return
+		}
+
+		scanSettings, err := json.Marshal(updateDto.ScanSettings)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			s.logger.Errorf("could not marshal scan settings of library %v: %v", id.String(), err.Error())
+			return
+		}
+		settings := string(scanSettings)
+		updateModel.ScanSettings = &settings
+		columns = append(columns, table.Library.ScanSettings)
+	}
 
-	updatedModel, err := s.repo.Library().Update(updateModel)
+	updatedModel, err := s.repo.Library().Update(updateModel, columns)
 	if err != nil {
 		c.AbortWithStatus(http.StatusInternalServerError)
 		s.logger.Errorf("could not update library %v: %v", id.String(), err.Error())
@@ -24,6 +24,11 @@ func (s *server) withLibraryPathGet(r *gin.RouterGroup, route Route) *server {
 	return s
 }
 
+func (s *server) withLibraryPathPut(r *gin.RouterGroup, route Route) *server {
+	r.PUT(fmt.Sprintf("%v/:%v", route, idKey), s.putLibraryPath)
+	return s
+}
+
 func (s *server) GetLibraryPath(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -85,3 +90,48 @@ func (s *server) GetAllLibraryPaths(c *gin.Context) {
 
 	c.JSON(http.StatusOK, libPaths)
 }
+
+const (
+	ErrUpdateLibraryPath   ApiError = "could not update library path"
+	ErrLibraryPathNotFound ApiError = "library path not found"
+)
+
+func (s *server) putLibraryPath(c *gin.Context) {
+	id, err := uuid.Parse(c.Param(idKey))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, createError(ErrInvalidIdFormat))
+		return
+	}
+
+	var updateDto dto.UpdateLibraryPathDTO
+	if err := c.ShouldBindBodyWithJSON(&updateDto); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	libraryPath, err := s.repo.LibraryPath().GetById(id)
+	if err != nil {
+		s.logger.Errorf("could not get library path %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrUpdateLibraryPath))
+		return
+	}
+
+	if libraryPath == nil {
+		c.JSON(http.StatusNotFound, createError(ErrLibraryPathNotFound))
+		return
+	}
+
+	libraryPath.Watch = updateDto.Watch
+	updatedModel, err := s.repo.LibraryPath().Update(*libraryPath)
+	if err != nil {
+		s.logger.Errorf("could not update library path %v: %v", id.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrUpdateLibraryPath))
+		return
+	}
+
+	if s.watcher != nil {
+		s.watcher.Reload()
+	}
+
+	c.JSON(http.StatusOK, (&dto.LibraryPathDTO{}).FromModel(*updatedModel))
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
 	"github.com/slugger7/exorcist/internal/dto"
+	mediaService "github.com/slugger7/exorcist/internal/service/media"
 )
 
 func (s *server) withMediaSearch(r *gin.RouterGroup, route Route) *server {
@@ -52,6 +54,112 @@ func (s *server) withMediaPut(r *gin.RouterGroup, route Route) *server {
 	return s
 }
 
+func (s *server) withMediaGetDuplicates(r *gin.RouterGroup, route Route) *server {
+	r.GET(fmt.Sprintf("%v/duplicates", route), s.getMediaDuplicates)
+	return s
+}
+
+func (s *server) withMediaGetNearDuplicates(r *gin.RouterGroup, route Route) *server {
+	r.GET(fmt.Sprintf("%v/near-duplicates", route), s.getMediaNearDuplicates)
+	return s
+}
+
+func (s *server) withMediaResolveDuplicates(r *gin.RouterGroup, route Route) *server {
+	r.POST(fmt.Sprintf("%v/duplicates/resolve", route), s.resolveMediaDuplicates)
+	return s
+}
+
+const (
+	ErrGetDuplicates     ApiError = "could not get duplicate media"
+	ErrGetNearDuplicates ApiError = "could not get near duplicate media"
+	ErrResolveDuplicates ApiError = "could not resolve duplicate media"
+	ErrNotDuplicate      ApiError = "media to remove is not a duplicate of the media that is kept"
+	ErrKeepNotFound      ApiError = "media to keep not found"
+)
+
+func (s *server) getMediaDuplicates(c *gin.Context) {
+	var search dto.DuplicateSearchDTO
+	if err := c.ShouldBindQuery(&search); err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if search.By == "" {
+		search.By = dto.DuplicateKey_Checksum
+	}
+
+	groups, err := s.service.Media().GetDuplicates(search.By)
+	if err != nil {
+		s.logger.Errorf("could not get duplicate media by %v: %v", search.By, err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetDuplicates))
+		return
+	}
+
+	dtos := make([]dto.DuplicateGroupDTO, len(groups))
+	for i, g := range groups {
+		dtos[i] = *(&dto.DuplicateGroupDTO{}).FromModel(g)
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+func (s *server) getMediaNearDuplicates(c *gin.Context) {
+	var search dto.NearDuplicateSearchDTO
+	if err := c.ShouldBindQuery(&search); err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if search.Threshold == 0 {
+		search.Threshold = dto.DefaultNearDuplicateThreshold
+	}
+
+	pairs, err := s.service.Media().GetNearDuplicates(search.Threshold)
+	if err != nil {
+		s.logger.Errorf("could not get near duplicate media with threshold %v: %v", search.Threshold, err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrGetNearDuplicates))
+		return
+	}
+
+	dtos := make([]dto.NearDuplicateDTO, len(pairs))
+	for i, p := range pairs {
+		dtos[i] = *(&dto.NearDuplicateDTO{}).FromModel(p)
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+func (s *server) resolveMediaDuplicates(c *gin.Context) {
+	var resolve dto.ResolveDuplicatesDTO
+	if err := c.ShouldBindBodyWithJSON(&resolve); err != nil {
+		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err := s.service.Media().ResolveDuplicates(resolve)
+	if errors.Is(err, mediaService.ErrNotDuplicate) {
+		c.JSON(http.StatusUnprocessableEntity, createError(ErrNotDuplicate))
+		return
+	}
+
+	if errors.Is(err, mediaService.ErrMediaNotFound) {
+		c.JSON(http.StatusNotFound, createError(ErrKeepNotFound))
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf("could not resolve duplicates of %v: %v", resolve.Keep.String(), err.Error())
+		c.JSON(http.StatusInternalServerError, createError(ErrResolveDuplicates))
+		return
+	}
+
+	for _, id := range resolve.Remove {
+		s.wsService.MediaDelete(dto.MediaOverviewDTO{Id: id, Deleted: true})
+	}
+
+	c.Status(http.StatusOK)
+}
+
 func (s *server) putMedia(c *gin.Context) {
 	id, err := uuid.Parse(c.Param(idKey))
 	if err != nil {
@@ -22,6 +22,7 @@ const (
 	people      Route = "/people"
 	tags        Route = "/tags"
 	playlists   Route = "/playlists"
+	schedules   Route = "/jobSchedules"
 )
 
 type key = string
@@ -68,10 +69,14 @@ func (s *server) RegisterRoutes() http.Handler {
 	s.withLibraryPathCreate(authenticated, libraryPath).
 		withLibraryPathGetAll(authenticated, libraryPath).
 		withLibraryPathGet(authenticated, libraryPath).
+		withLibraryPathPut(authenticated, libraryPath).
 		withLibraryPut(authenticated, libraries)
 
 	// Register media controller routes
 	s.withMediaSearch(authenticated, media).
+		withMediaGetDuplicates(authenticated, media).
+		withMediaGetNearDuplicates(authenticated, media).
+		withMediaResolveDuplicates(authenticated, media).
 		withMediaGet(authenticated, media).
 		withMediaPutTag(authenticated, media).
 		withMediaDeleteTag(authenticated, media).
@@ -87,7 +92,18 @@ func (s *server) RegisterRoutes() http.Handler {
 	// Register job controller routes
 	s.withJobRoutes(authenticated, jobs).
 		withJobCreate(authenticated, jobs).
-		withJobGetAll(authenticated, jobs)
+		withJobGetAll(authenticated, jobs).
+		withJobGetTree(authenticated, jobs).
+		withJobGetLogs(authenticated, jobs).
+		withJobCancel(authenticated, jobs).
+		withJobCancelChildren(authenticated, jobs)
+
+	// Register job schedule controller routes
+	s.withJobScheduleGetAll(authenticated, schedules).
+		withJobScheduleGet(authenticated, schedules).
+		withJobScheduleCreate(authenticated, schedules).
+		withJobSchedulePut(authenticated, schedules).
+		withJobScheduleDelete(authenticated, schedules)
 
 	// Register person controller routes
 	s.withPersonGetAll(authenticated, people).
@@ -12,6 +12,7 @@ import (
 	"github.com/slugger7/exorcist/internal/logger"
 	"github.com/slugger7/exorcist/internal/repository"
 	"github.com/slugger7/exorcist/internal/service"
+	"github.com/slugger7/exorcist/internal/watcher"
 	"github.com/slugger7/exorcist/internal/websockets"
 )
 
@@ -22,6 +23,7 @@ type server struct {
 	logger    logger.Logger
 	jobCh     chan bool
 	wsService websockets.Websockets
+	watcher   *watcher.Watcher
 }
 
 func (s *server) withJobRunner(ctx context.Context, wg *sync.WaitGroup, ws websockets.Websockets) *server {
@@ -33,6 +35,12 @@ func (s *server) withJobRunner(ctx context.Context, wg *sync.WaitGroup, ws webso
 	return s
 }
 
+func (s *server) withWatcher(ctx context.Context, wg *sync.WaitGroup, ws websockets.Websockets) *server {
+	s.watcher = watcher.New(s.env, s.repo, s.logger, ctx, wg, ws)
+
+	return s
+}
+
 func New(env *environment.EnvironmentVariables, wg *sync.WaitGroup) *http.Server {
 	lg := logger.New(env)
 	shutdownCtx, cancel := context.WithCancel(context.Background())
@@ -46,15 +54,15 @@ func New(env *environment.EnvironmentVariables, wg *sync.WaitGroup) *http.Server
 		wsService: websockets.New(env),
 	}
 
-	err := newServer.repo.Job().CancelInprogress()
-	if err != nil {
-		lg.Errorf("clearing in progress jobs on startup: %v", err.Error())
-	}
+	job.RecoverInterrupted(env, newServer.repo, lg)
 
 	if env.JobRunner {
 		newServer.withJobRunner(shutdownCtx, wg, newServer.wsService)
 	}
-	newServer.service = service.New(repo, env, newServer.jobCh, shutdownCtx)
+	if env.Watcher {
+		newServer.withWatcher(shutdownCtx, wg, newServer.wsService)
+	}
+	newServer.service = service.New(repo, env, shutdownCtx, job.Registry)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", env.Port),
@@ -67,7 +75,9 @@ func New(env *environment.EnvironmentVariables, wg *sync.WaitGroup) *http.Server
 	server.RegisterOnShutdown(func() {
 		newServer.logger.Info("Shutting down server. Stopping job runner.")
 		cancel()
-		close(newServer.jobCh)
+		if newServer.jobCh != nil {
+			close(newServer.jobCh)
+		}
 
 		newServer.logger.Debug("Cancelled and closed")
 
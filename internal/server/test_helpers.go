@@ -17,9 +17,11 @@ import (
 	"github.com/slugger7/exorcist/internal/environment"
 	"github.com/slugger7/exorcist/internal/logger"
 	mock_service "github.com/slugger7/exorcist/internal/mock/service"
+	mock_jobScheduleService "github.com/slugger7/exorcist/internal/mock/service/job_schedule"
 	mock_libraryService "github.com/slugger7/exorcist/internal/mock/service/library"
 	mock_libraryPathService "github.com/slugger7/exorcist/internal/mock/service/library_path"
 	mock_userService "github.com/slugger7/exorcist/internal/mock/service/user"
+	jobScheduleService "github.com/slugger7/exorcist/internal/service/job_schedule"
 	libraryService "github.com/slugger7/exorcist/internal/service/library"
 	libraryPathService "github.com/slugger7/exorcist/internal/service/library_path"
 	userService "github.com/slugger7/exorcist/internal/service/user"
@@ -40,6 +42,7 @@ type TestServer struct {
 	mockUserService        *mock_userService.MockUserService
 	mockLibraryService     *mock_libraryService.MockLibraryService
 	mockLibraryPathService *mock_libraryPathService.MockLibraryPathService
+	mockJobScheduleService *mock_jobScheduleService.MockJobScheduleService
 	ctrl                   *gomock.Controller
 	engine                 *gin.Engine
 	authGroup              *gin.RouterGroup
@@ -100,6 +103,21 @@ func (s *TestServer) withLibraryPathService() *TestServer {
 	return s
 }
 
+func (s *TestServer) withJobScheduleService() *TestServer {
+	js := mock_jobScheduleService.NewMockJobScheduleService(s.ctrl)
+
+	s.mockService.EXPECT().
+		JobSchedule().
+		DoAndReturn(func() jobScheduleService.JobScheduleService {
+			return js
+		}).
+		AnyTimes()
+
+	s.mockJobScheduleService = js
+
+	return s
+}
+
 func (s *TestServer) withCookie(cookie TestCookie) *TestServer {
 	rr := httptest.NewRecorder()
 	cookieReq, _ := http.NewRequest("GET", SET_COOKIE_URL, bodyM(cookie))
@@ -164,6 +182,18 @@ func (s *TestServer) withAuthPutRequest(body io.Reader, params string) *TestServ
 	return s
 }
 
+func (s *TestServer) withPutRequest(body io.Reader, params string) *TestServer {
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/%v", params), body)
+	s.request = req
+	return s
+}
+
+func (s *TestServer) withDeleteRequest(params string) *TestServer {
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/%v", params), nil)
+	s.request = req
+	return s
+}
+
 func (s *TestServer) exec() *httptest.ResponseRecorder {
 	rr := httptest.NewRecorder()
 	s.engine.ServeHTTP(rr, s.request)
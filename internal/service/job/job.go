@@ -2,40 +2,46 @@ package jobService
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
 
+	"github.com/google/uuid"
 	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
 	"github.com/slugger7/exorcist/internal/dto"
 	"github.com/slugger7/exorcist/internal/environment"
 	errs "github.com/slugger7/exorcist/internal/errors"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/models"
 	"github.com/slugger7/exorcist/internal/repository"
 )
 
 type JobService interface {
+	Build(dto.CreateJobDTO) (*model.Job, error)
 	Create(dto.CreateJobDTO) (*model.Job, error)
+	Cancel(id uuid.UUID) (*model.Job, error)
+	CancelByParent(parent uuid.UUID) ([]model.Job, error)
+	GetTree(id uuid.UUID) (*models.JobTree, error)
 }
 
 type jobService struct {
 	env    *environment.EnvironmentVariables
 	repo   repository.Repository
 	logger logger.Logger
-	jobCh  chan bool
 	ctx    context.Context
+	jobs   jobRegistry.Builder
 }
 
 var jobServiceInstance *jobService
 
-func New(repo repository.Repository, env *environment.EnvironmentVariables, jobCh chan bool, ctx context.Context) JobService {
+func New(repo repository.Repository, env *environment.EnvironmentVariables, ctx context.Context, jobs jobRegistry.Builder) JobService {
 	if jobServiceInstance == nil {
 		jobServiceInstance = &jobService{
 			env:    env,
 			repo:   repo,
 			logger: logger.New(env),
-			jobCh:  jobCh,
 			ctx:    ctx,
+			jobs:   jobs,
 		}
 
 		jobServiceInstance.logger.Info("UserService instance created")
@@ -43,218 +49,112 @@ func New(repo repository.Repository, env *environment.EnvironmentVariables, jobC
 	return jobServiceInstance
 }
 
-func (s *jobService) Create(m dto.CreateJobDTO) (*model.Job, error) {
-	defaultJobPriority := dto.JobPriority_Medium
-	if m.Priority == nil {
-		m.Priority = &(defaultJobPriority)
-	}
-	data, err := json.Marshal(m.Data)
-	strData := string(data)
+// ErrJobNotFound is returned when there is no job with the id
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobFinished is returned when a job or its child jobs can not be cancelled as they already finished
+var ErrJobFinished = errors.New("job already finished")
+
+// Cancel implements JobService.
+// A running job is stopped by the job runner once it sees the cancelled status.
+func (s *jobService) Cancel(id uuid.UUID) (*model.Job, error) {
+	job, err := s.repo.Job().GetById(id)
 	if err != nil {
-		return nil, errs.BuildError(err, "could not marhsal data field")
-	}
-	var j *model.Job
-	var e error
-	switch m.Type {
-	case model.JobTypeEnum_ScanPath:
-		j, e = s.scanPath(strData, *m.Priority)
-	case model.JobTypeEnum_GenerateThumbnail:
-		j, e = s.generateThumbnail(strData, *m.Priority)
-	case model.JobTypeEnum_RefreshMetadata:
-		j, e = s.refreshMetadata(strData, *m.Priority)
-	case model.JobTypeEnum_RefreshLibraryMetadata:
-		j, e = s.refreshLibraryMetadata(strData, *m.Priority)
-	case model.JobTypeEnum_GenerateChapters:
-		j, e = s.generateChapters(strData, *m.Priority)
-	default:
-		return nil, fmt.Errorf("job type not implemented: %v", m.Type)
-	}
-	if e != nil {
-		return nil, errs.BuildError(err, "error encountered while creating job")
+		return nil, errs.BuildError(err, "getting job by id: %v", id.String())
 	}
 
-	job := model.Job{
-		JobType:  m.Type,
-		Status:   model.JobStatusEnum_NotStarted,
-		Data:     j.Data,
-		Priority: j.Priority,
+	if job == nil {
+		return nil, fmt.Errorf("%w: %v", ErrJobNotFound, id.String())
 	}
 
-	jobs, err := s.repo.Job().CreateAll([]model.Job{job})
+	cancelledJob, err := s.repo.Job().Cancel(id)
 	if err != nil {
-		return nil, errs.BuildError(err, "creating job")
+		return nil, errs.BuildError(err, "cancelling job: %v", id.String())
 	}
 
-	if len(jobs) == 0 {
-		return nil, fmt.Errorf("no jobs were returned after creating a job")
+	if cancelledJob == nil {
+		return nil, fmt.Errorf("%w: job %v could not be cancelled as it is already %v", ErrJobFinished, id.String(), job.Status)
 	}
 
-	go s.startJobRunner()
-
-	return &jobs[0], nil
+	return cancelledJob, nil
 }
 
-func (i *jobService) generateChapters(data string, priority int16) (*model.Job, error) {
-	var jobData dto.GenerateChaptersData
-	if err := json.Unmarshal([]byte(data), &jobData); err != nil {
-		return nil, errs.BuildError(err, "unmarshalling data for generate chapters data: %v", data)
-	}
-
-	if jobData.Interval == 0 {
-		jobData.Interval = float64(((time.Minute * 5).Seconds()))
-	}
-
-	media, err := i.repo.Media().GetById(jobData.MediaId)
+// CancelByParent implements JobService.
+func (s *jobService) CancelByParent(parent uuid.UUID) ([]model.Job, error) {
+	job, err := s.repo.Job().GetById(parent)
 	if err != nil {
-		return nil, errs.BuildError(err, "getting media by id: %v", jobData.MediaId.String())
+		return nil, errs.BuildError(err, "getting parent job by id: %v", parent.String())
 	}
 
-	if media == nil {
-		return nil, fmt.Errorf("no media with id: %v", jobData.MediaId.String())
+	if job == nil {
+		return nil, fmt.Errorf("%w: %v", ErrJobNotFound, parent.String())
 	}
 
-	if media.Video == nil {
-		return nil, fmt.Errorf("media is not of type video: %v", jobData.MediaId.String())
-	}
-
-	bytes, err := json.Marshal(jobData)
+	jobs, err := s.repo.Job().CancelByParent(parent)
 	if err != nil {
-		return nil, errs.BuildError(err, "could not remarshall generate chapters data")
+		return nil, errs.BuildError(err, "cancelling jobs with parent: %v", parent.String())
 	}
 
-	data = string(bytes)
-
-	return &model.Job{
-		Data:     &data,
-		Priority: priority,
-	}, nil
-}
-
-func (i *jobService) refreshLibraryMetadata(data string, priority int16) (*model.Job, error) {
-	var jobData dto.RefreshLibraryMetadata
-	if err := json.Unmarshal([]byte(data), &jobData); err != nil {
-		return nil, errs.BuildError(err, "unmarshalling data for refresh library metadata: %v", data)
+	// a parent that is still running can create more child jobs so it only counts as finished once the parent did
+	running := job.Status == model.JobStatusEnum_NotStarted || job.Status == model.JobStatusEnum_InProgress
+	if len(jobs) == 0 && !running {
+		return nil, fmt.Errorf("%w: job %v is %v and has no child jobs left to cancel", ErrJobFinished, parent.String(), job.Status)
 	}
 
-	if jobData.RefreshFields == nil {
-		jobData.RefreshFields = &dto.RefreshFields{
-			Size:     true,
-			Checksum: false,
-		}
-	}
+	return jobs, nil
+}
 
-	library, err := i.repo.Library().GetById(jobData.LibraryId)
+// GetTree implements JobService.
+func (s *jobService) GetTree(id uuid.UUID) (*models.JobTree, error) {
+	job, err := s.repo.Job().GetById(id)
 	if err != nil {
-		return nil, errs.BuildError(err, "getting library by id: %v", jobData.LibraryId.String())
+		return nil, errs.BuildError(err, "getting job by id: %v", id.String())
 	}
 
-	if library == nil {
-		return nil, fmt.Errorf("no library found with id: %v", jobData.LibraryId.String())
+	if job == nil {
+		return nil, fmt.Errorf("%w: %v", ErrJobNotFound, id.String())
 	}
 
-	bytes, err := json.Marshal(jobData)
+	descendants, err := s.repo.Job().GetDescendantStatusCounts(id)
 	if err != nil {
-		return nil, errs.BuildError(err, "remarshalling refresh library metadata")
+		return nil, errs.BuildError(err, "counting descendants of job: %v", id.String())
 	}
 
-	data = string(bytes)
-
-	return &model.Job{
-		Data:     &data,
-		Priority: priority,
+	return &models.JobTree{
+		Job:         *job,
+		Descendants: descendants,
 	}, nil
 }
 
-func (i *jobService) refreshMetadata(data string, priority int16) (*model.Job, error) {
-	var jobData dto.RefreshMetadata
-	if err := json.Unmarshal([]byte(data), &jobData); err != nil {
-		return nil, errs.BuildError(err, "unmarshalling data for refresh meta data: %v", data)
-	}
-
-	mediaEntity, err := i.repo.Media().GetById(jobData.MediaId)
-	if err != nil {
-		return nil, errs.BuildError(err, "fetching media entity by id: %v", jobData.MediaId.String())
-	}
-
-	if mediaEntity == nil {
-		return nil, fmt.Errorf("no media entity found to refresh the metada of: %v", jobData.MediaId.String())
-	}
-
-	return &model.Job{
-		Data:     &data,
-		Priority: priority,
-	}, nil
-
+// Build implements JobService.
+// Validates the job data and returns the job that would be created without persisting it
+func (s *jobService) Build(m dto.CreateJobDTO) (*model.Job, error) {
+	return s.jobs.Build(s.repo, m)
 }
 
-const ErrActionGenerateThumbnailVideoNotFound = "could not find video for generate thumbnail job: %v"
-
-func (i *jobService) generateThumbnail(data string, priority int16) (*model.Job, error) {
-	var generateThumbnailData dto.GenerateThumbnailData
-
-	if err := json.Unmarshal([]byte(data), &generateThumbnailData); err != nil {
-		return nil, errs.BuildError(err, "could not unmarshal data for job %v", data)
-	}
-
-	if generateThumbnailData.RelationType == nil {
-		v := model.MediaRelationTypeEnum_Thumbnail
-		generateThumbnailData.RelationType = &v
-	}
+// ErrDuplicateJob is returned when a job that does the same work is already pending or running
+var ErrDuplicateJob = errors.New("a job doing the same work is already pending or running")
 
-	if _, err := i.repo.Video().GetByIdWithMedia(generateThumbnailData.MediaId); err != nil {
-		return nil, errs.BuildError(
-			err,
-			ErrActionGenerateThumbnailVideoNotFound,
-			generateThumbnailData.MediaId)
+// Create implements JobService.
+func (s *jobService) Create(m dto.CreateJobDTO) (*model.Job, error) {
+	job, err := s.Build(m)
+	if err != nil {
+		return nil, err
 	}
 
-	bytes, err := json.Marshal(generateThumbnailData)
+	jobs, skipped, err := s.repo.Job().CreateAll([]model.Job{*job})
 	if err != nil {
-		return nil, errs.BuildError(err, "could not remarshal generate thumbnail data")
+		return nil, errs.BuildError(err, "creating job")
 	}
 
-	data = string(bytes)
-
-	return &model.Job{
-		Data:     &data,
-		Priority: priority,
-	}, nil
-}
-
-const ErrActionScanGetLibraryPaths = "could not get library paths in scan action"
-const ErrCreatingJobs = "error creating jobs"
-
-func (i *jobService) scanPath(data string, priority int16) (*model.Job, error) {
-	var scanPathData dto.ScanPathData
-
-	if err := json.Unmarshal([]byte(data), &scanPathData); err != nil {
-		return nil, errs.BuildError(err, "could not unmarshall data for job %v", data)
+	if len(skipped) > 0 {
+		s.logger.Infof("Skipped %v job as a job with dedup key %v is already pending or running", skipped[0].JobType, *skipped[0].DedupKey)
+		return nil, fmt.Errorf("%w: %v", ErrDuplicateJob, *skipped[0].DedupKey)
 	}
 
-	// check to see if the library path actually exists before creating a job
-	_, err := i.repo.LibraryPath().GetById(scanPathData.LibraryPathId)
-	if err != nil {
-		return nil, errs.BuildError(err, ErrActionScanGetLibraryPaths)
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no jobs were returned after creating a job")
 	}
 
-	return &model.Job{
-		Data:     &data,
-		Priority: priority,
-	}, nil
-}
-
-// We do this at the moment to stack a signal to the job runner if it is already running
-func (i *jobService) startJobRunner() {
-	i.logger.Debug("Starting a job runner")
-	select {
-	case <-i.ctx.Done():
-		i.logger.Debug("Shutdown signal recieved. Not starting job runner")
-		return
-	default:
-		i.logger.Debug("Starting job runner")
-		if i.env.JobRunner {
-			i.jobCh <- true
-			i.logger.Debug("Job runner signal sent")
-		}
-	}
+	return &jobs[0], nil
 }
@@ -0,0 +1,267 @@
+package jobScheduleService
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/cron"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/table"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/environment"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/repository"
+	jobService "github.com/slugger7/exorcist/internal/service/job"
+)
+
+type JobScheduleService interface {
+	Create(m dto.CreateJobScheduleDTO) (*model.JobSchedule, error)
+	Update(id uuid.UUID, m dto.UpdateJobScheduleDTO) (*model.JobSchedule, error)
+	Delete(id uuid.UUID) error
+	RunDue(now time.Time) ([]model.Job, error)
+}
+
+type jobScheduleService struct {
+	env        *environment.EnvironmentVariables
+	repo       repository.Repository
+	logger     logger.Logger
+	jobService jobService.JobService
+}
+
+var jobScheduleServiceInstance *jobScheduleService
+
+func New(env *environment.EnvironmentVariables, repo repository.Repository, jobService jobService.JobService) JobScheduleService {
+	if jobScheduleServiceInstance == nil {
+		jobScheduleServiceInstance = &jobScheduleService{
+			env:        env,
+			repo:       repo,
+			logger:     logger.New(env),
+			jobService: jobService,
+		}
+
+		jobScheduleServiceInstance.logger.Info("JobScheduleService instance created")
+	}
+
+	return jobScheduleServiceInstance
+}
+
+// ErrJobScheduleNotFound is returned when there is no job schedule with the id
+var ErrJobScheduleNotFound = errors.New("job schedule not found")
+
+// ErrInvalidJobSchedule is returned when the cron expression or the job of a job schedule is not valid
+var ErrInvalidJobSchedule = errors.New("invalid job schedule")
+
+// Create implements JobScheduleService.
+func (s *jobScheduleService) Create(m dto.CreateJobScheduleDTO) (*model.JobSchedule, error) {
+	schedule, err := cron.Parse(m.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cron expression %v: %w", ErrInvalidJobSchedule, m.Cron, err)
+	}
+
+	job, err := s.jobService.Build(dto.CreateJobDTO{
+		Type:     m.Type,
+		Data:     m.Data,
+		Priority: m.Priority,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJobSchedule, err)
+	}
+
+	enabled := true
+	if m.Enabled != nil {
+		enabled = *m.Enabled
+	}
+
+	jobSchedule := model.JobSchedule{
+		Name:     m.Name,
+		Cron:     m.Cron,
+		JobType:  job.JobType,
+		Data:     job.Data,
+		Priority: job.Priority,
+		Enabled:  enabled,
+		NextRun:  nextRun(schedule, time.Now()),
+	}
+
+	created, err := s.repo.JobSchedule().Create(jobSchedule)
+	if err != nil {
+		return nil, errs.BuildError(err, "creating job schedule")
+	}
+
+	return created, nil
+}
+
+// Update implements JobScheduleService.
+func (s *jobScheduleService) Update(id uuid.UUID, m dto.UpdateJobScheduleDTO) (*model.JobSchedule, error) {
+	jobSchedule, err := s.repo.JobSchedule().GetById(id)
+	if err != nil {
+		return nil, errs.BuildError(err, "getting job schedule by id: %v", id.String())
+	}
+
+	if jobSchedule == nil {
+		return nil, fmt.Errorf("%w: %v", ErrJobScheduleNotFound, id.String())
+	}
+
+	columns := postgres.ColumnList{}
+	if m.Name != nil {
+		jobSchedule.Name = *m.Name
+		columns = append(columns, table.JobSchedule.Name)
+	}
+
+	if m.Data != nil || m.Priority != nil {
+		data := m.Data
+		if data == nil && jobSchedule.Data != nil {
+			if err := json.Unmarshal([]byte(*jobSchedule.Data), &data); err != nil {
+				return nil, errs.BuildError(err, "unmarshalling existing job schedule data")
+			}
+		}
+
+		priority := m.Priority
+		if priority == nil {
+			priority = &jobSchedule.Priority
+		}
+
+		job, err := s.jobService.Build(dto.CreateJobDTO{
+			Type:     jobSchedule.JobType,
+			Data:     data,
+			Priority: priority,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidJobSchedule, err)
+		}
+
+		jobSchedule.Data = job.Data
+		jobSchedule.Priority = job.Priority
+		columns = append(columns, table.JobSchedule.Data, table.JobSchedule.Priority)
+	}
+
+	rescheduled := false
+	if m.Cron != nil {
+		jobSchedule.Cron = *m.Cron
+		rescheduled = true
+		columns = append(columns, table.JobSchedule.Cron)
+	}
+
+	if m.Enabled != nil {
+		rescheduled = rescheduled || (*m.Enabled && !jobSchedule.Enabled)
+		jobSchedule.Enabled = *m.Enabled
+		columns = append(columns, table.JobSchedule.Enabled)
+	}
+
+	if rescheduled {
+		schedule, err := cron.Parse(jobSchedule.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cron expression %v: %w", ErrInvalidJobSchedule, jobSchedule.Cron, err)
+		}
+
+		jobSchedule.NextRun = nextRun(schedule, time.Now())
+		columns = append(columns, table.JobSchedule.NextRun)
+	}
+
+	if len(columns) == 0 {
+		return jobSchedule, nil
+	}
+
+	updated, err := s.repo.JobSchedule().Update(*jobSchedule, columns)
+	if err != nil {
+		return nil, errs.BuildError(err, "updating job schedule: %v", id.String())
+	}
+
+	return updated, nil
+}
+
+// Delete implements JobScheduleService.
+func (s *jobScheduleService) Delete(id uuid.UUID) error {
+	jobSchedule, err := s.repo.JobSchedule().GetById(id)
+	if err != nil {
+		return errs.BuildError(err, "getting job schedule by id: %v", id.String())
+	}
+
+	if jobSchedule == nil {
+		return fmt.Errorf("%w: %v", ErrJobScheduleNotFound, id.String())
+	}
+
+	if err := s.repo.JobSchedule().Delete(id); err != nil {
+		return errs.BuildError(err, "deleting job schedule: %v", id.String())
+	}
+
+	return nil
+}
+
+// RunDue implements JobScheduleService.
+// Creates a job for every enabled schedule that is due and moves the schedule on to its next run.
+// A schedule that is claimed by another process in the mean time is skipped.
+func (s *jobScheduleService) RunDue(now time.Time) ([]model.Job, error) {
+	schedules, err := s.repo.JobSchedule().GetDue(now)
+	if err != nil {
+		return nil, errs.BuildError(err, "getting due job schedules")
+	}
+
+	jobs := []model.Job{}
+	for _, jobSchedule := range schedules {
+		schedule, err := cron.Parse(jobSchedule.Cron)
+		if err != nil {
+			s.logger.Errorf("job schedule %v has an invalid cron expression %v: %v", jobSchedule.ID.String(), jobSchedule.Cron, err.Error())
+			continue
+		}
+
+		var next time.Time
+		if n := nextRun(schedule, now); n != nil {
+			next = *n
+		}
+
+		claimed, err := s.repo.JobSchedule().ClaimRun(jobSchedule, now, next)
+		if err != nil {
+			s.logger.Errorf("could not claim run for job schedule %v: %v", jobSchedule.ID.String(), err.Error())
+			continue
+		}
+
+		if !claimed {
+			s.logger.Debugf("job schedule %v was already claimed", jobSchedule.ID.String())
+			continue
+		}
+
+		var data map[string]interface{}
+		if jobSchedule.Data != nil {
+			if err := json.Unmarshal([]byte(*jobSchedule.Data), &data); err != nil {
+				s.logger.Errorf("could not unmarshal data of job schedule %v: %v", jobSchedule.ID.String(), err.Error())
+				continue
+			}
+		}
+
+		priority := jobSchedule.Priority
+		job, err := s.jobService.Create(dto.CreateJobDTO{
+			Type:     jobSchedule.JobType,
+			Data:     data,
+			Priority: &priority,
+		})
+		if errors.Is(err, jobService.ErrDuplicateJob) {
+			s.logger.Infof("Skipped run of job schedule %v as its previous job is still pending or running", jobSchedule.Name)
+			continue
+		}
+
+		if err != nil {
+			s.logger.Errorf("could not create job for job schedule %v: %v", jobSchedule.ID.String(), err.Error())
+			continue
+		}
+
+		s.logger.Infof("Created %v job %v from job schedule %v", job.JobType, job.ID.String(), jobSchedule.Name)
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, nil
+}
+
+func nextRun(schedule *cron.Schedule, from time.Time) *time.Time {
+	next := schedule.Next(from)
+	if next.IsZero() {
+		return nil
+	}
+
+	next = next.UTC()
+	return &next
+}
@@ -0,0 +1,167 @@
+package mediaService
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/models"
+)
+
+// GetDuplicates implements MediaService.
+func (m *mediaService) GetDuplicates(by dto.DuplicateKey) ([][]models.DuplicateMedia, error) {
+	duplicates, err := m.repo.Media().GetDuplicates(by)
+	if err != nil {
+		return nil, errs.BuildError(err, "could not get duplicate media from repo")
+	}
+
+	return groupDuplicates(duplicates, by), nil
+}
+
+// groupDuplicates groups the media by checksum or by size key in the order that the groups were first found.
+// Groups without duplicates are left out
+func groupDuplicates(duplicates []models.DuplicateMedia, by dto.DuplicateKey) [][]models.DuplicateMedia {
+	keys := []string{}
+	groups := map[string][]models.DuplicateMedia{}
+	for _, d := range duplicates {
+		key := duplicateKey(d, by)
+		if key == "" {
+			continue
+		}
+
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], d)
+	}
+
+	result := [][]models.DuplicateMedia{}
+	for _, k := range keys {
+		if len(groups[k]) > 1 {
+			result = append(result, groups[k])
+		}
+	}
+
+	return result
+}
+
+func duplicateKey(d models.DuplicateMedia, by dto.DuplicateKey) string {
+	if by == dto.DuplicateKey_SizeRuntime {
+		return sizeKey(d.Media, d.Video, d.Image)
+	}
+
+	if by == dto.DuplicateKey_PartialChecksum {
+		if d.PartialChecksum == nil {
+			return ""
+		}
+		return *d.PartialChecksum
+	}
+
+	if d.Checksum == nil {
+		return ""
+	}
+	return *d.Checksum
+}
+
+// ErrNotDuplicate is returned when media that should be removed is not a duplicate of the media that is kept
+var ErrNotDuplicate = errors.New("media is not a duplicate of the media that is kept")
+
+// ErrMediaNotFound is returned when the media that should be kept does not exist
+var ErrMediaNotFound = errors.New("media not found")
+
+// ResolveDuplicates implements MediaService.
+// All of the media is checked before anything is merged or deleted. The duplicates are merged and deleted in a single
+// transaction and their files are only removed once it was committed
+func (m *mediaService) ResolveDuplicates(resolve dto.ResolveDuplicatesDTO) error {
+	keep, err := m.repo.Media().GetById(resolve.Keep)
+	if err != nil {
+		return errs.BuildError(err, "could not get media to keep: %v", resolve.Keep)
+	}
+	if keep == nil {
+		return fmt.Errorf("%w: media to keep does not exist: %v", ErrMediaNotFound, resolve.Keep)
+	}
+
+	duplicates := []models.Media{}
+	deleted := []model.Media{}
+	for _, id := range resolve.Remove {
+		if id == resolve.Keep {
+			return fmt.Errorf("%w: media that is kept can not be removed: %v", ErrNotDuplicate, id)
+		}
+
+		duplicate, err := m.repo.Media().GetById(id)
+		if err != nil {
+			return errs.BuildError(err, "could not get duplicate media: %v", id)
+		}
+		if duplicate == nil || !isDuplicate(*keep, *duplicate) {
+			return fmt.Errorf("%w: media %v is not a duplicate of %v", ErrNotDuplicate, id, resolve.Keep)
+		}
+		duplicates = append(duplicates, *duplicate)
+
+		assets, err := m.repo.Media().GetAssetsFor(id)
+		if err != nil {
+			return errs.BuildError(err, "could not find assets for: %v", id)
+		}
+		deleted = append(deleted, deletedMedia(duplicate.Media, assets, resolve.Physical)...)
+	}
+
+	if err := m.repo.Media().ResolveDuplicates(resolve.Keep, resolve.Remove, deleted); err != nil {
+		return errs.BuildError(err, "could not resolve duplicates of %v", resolve.Keep)
+	}
+
+	if !resolve.Physical {
+		return nil
+	}
+
+	accErrs := []error{}
+	for _, d := range duplicates {
+		if err := m.removeFiles(d.Media); err != nil {
+			accErrs = append(accErrs, err)
+		}
+	}
+
+	return errors.Join(accErrs...)
+}
+
+// isDuplicate compares checksums of the same algorithm when both have one, then partial checksums and otherwise
+// compares the size key
+func isDuplicate(keep, duplicate models.Media) bool {
+	if keep.Checksum != nil && duplicate.Checksum != nil && sameAlgorithm(keep.ChecksumAlgorithm, duplicate.ChecksumAlgorithm) {
+		return *keep.Checksum == *duplicate.Checksum
+	}
+
+	if keep.PartialChecksum != nil && duplicate.PartialChecksum != nil &&
+		sameAlgorithm(keep.PartialChecksumAlgorithm, duplicate.PartialChecksumAlgorithm) {
+		return *keep.PartialChecksum == *duplicate.PartialChecksum
+	}
+
+	key := sizeKey(keep.Media, keep.Video, keep.Image)
+	return key != "" && key == sizeKey(duplicate.Media, duplicate.Video, duplicate.Image)
+}
+
+// sameAlgorithm treats checksums without an algorithm as md5 which was the only algorithm before it was recorded
+func sameAlgorithm(a, b *model.ChecksumAlgorithmEnum) bool {
+	algorithmOf := func(algorithm *model.ChecksumAlgorithmEnum) model.ChecksumAlgorithmEnum {
+		if algorithm == nil {
+			return model.ChecksumAlgorithmEnum_Md5
+		}
+		return *algorithm
+	}
+
+	return algorithmOf(a) == algorithmOf(b)
+}
+
+// sizeKey is the size and runtime of videos and the size and dimensions of images. Images have no runtime so their
+// dimensions have to match as well. Media that is neither has no key
+func sizeKey(m model.Media, video *model.Video, image *model.Image) string {
+	if video != nil {
+		return fmt.Sprintf("video:%v:%v", m.Size, video.Runtime)
+	}
+
+	if image != nil {
+		return fmt.Sprintf("image:%v:%vx%v", m.Size, image.Width, image.Height)
+	}
+
+	return ""
+}
@@ -0,0 +1,179 @@
+package mediaService
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GroupDuplicates_ByChecksum_ShouldLeaveOutMediaWithoutDuplicates(t *testing.T) {
+	a, b := "a", "b"
+	duplicates := []models.DuplicateMedia{
+		{Media: model.Media{ID: uuid.New(), Checksum: &a}},
+		{Media: model.Media{ID: uuid.New(), Checksum: &b}},
+		{Media: model.Media{ID: uuid.New(), Checksum: &a}},
+		{Media: model.Media{ID: uuid.New()}},
+	}
+
+	groups := groupDuplicates(duplicates, dto.DuplicateKey_Checksum)
+
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("expected one group of two duplicates but got: %v", groups)
+	}
+	if groups[0][0].Media.ID != duplicates[0].Media.ID || groups[0][1].Media.ID != duplicates[2].Media.ID {
+		t.Errorf("expected the group to keep the order of the media")
+	}
+}
+
+func Test_GroupDuplicates_BySizeRuntime_ShouldCompareRuntime(t *testing.T) {
+	duplicates := []models.DuplicateMedia{
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Video: &model.Video{Runtime: 60}},
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Video: &model.Video{Runtime: 61}},
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Video: &model.Video{Runtime: 60}},
+	}
+
+	groups := groupDuplicates(duplicates, dto.DuplicateKey_SizeRuntime)
+
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("expected one group of two duplicates but got: %v", groups)
+	}
+}
+
+func Test_GroupDuplicates_BySizeRuntime_ShouldCompareTheDimensionsOfImages(t *testing.T) {
+	duplicates := []models.DuplicateMedia{
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Image: &model.Image{Width: 100, Height: 50}},
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Image: &model.Image{Width: 50, Height: 100}},
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Image: &model.Image{Width: 100, Height: 50}},
+		{Media: model.Media{ID: uuid.New(), Size: 10}, Video: &model.Video{Runtime: 0}},
+	}
+
+	groups := groupDuplicates(duplicates, dto.DuplicateKey_SizeRuntime)
+
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("expected one group of two duplicates but got: %v", groups)
+	}
+	if groups[0][0].Media.ID != duplicates[0].Media.ID || groups[0][1].Media.ID != duplicates[2].Media.ID {
+		t.Errorf("expected the images with the same dimensions to be grouped but got: %v", groups)
+	}
+}
+
+func Test_ResolveDuplicates_WithMediaThatIsNotADuplicate_ShouldNotMergeOrDelete(t *testing.T) {
+	s := setup(t)
+	defer s.cleanup()
+
+	a, b := "a", "b"
+	keep := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &a}}
+	other := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &b}}
+
+	s.mediaRepo.EXPECT().GetById(keep.Media.ID).Return(&keep, nil).Times(1)
+	s.mediaRepo.EXPECT().GetById(other.Media.ID).Return(&other, nil).Times(1)
+	s.mediaRepo.EXPECT().ResolveDuplicates(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := s.svc.ResolveDuplicates(dto.ResolveDuplicatesDTO{Keep: keep.Media.ID, Remove: []uuid.UUID{other.Media.ID}})
+	if !errors.Is(err, ErrNotDuplicate) {
+		t.Errorf("expected a not duplicate error but got: %v", err)
+	}
+}
+
+func Test_ResolveDuplicates_ShouldMergeAndDeleteTheDuplicatesTogether(t *testing.T) {
+	s := setup(t)
+	defer s.cleanup()
+
+	a := "a"
+	keep := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &a}}
+	duplicate := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &a, Exists: true}}
+	other := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &a, Exists: true}}
+
+	s.mediaRepo.EXPECT().GetById(keep.Media.ID).Return(&keep, nil).Times(1)
+	s.mediaRepo.EXPECT().GetById(duplicate.Media.ID).Return(&duplicate, nil).Times(1)
+	s.mediaRepo.EXPECT().GetById(other.Media.ID).Return(&other, nil).Times(1)
+	asset := model.Media{ID: uuid.New(), Exists: true}
+	s.mediaRepo.EXPECT().GetAssetsFor(duplicate.Media.ID).Return([]model.Media{asset}, nil).Times(1)
+	s.mediaRepo.EXPECT().GetAssetsFor(other.Media.ID).Return([]model.Media{}, nil).Times(1)
+
+	deletedAsset, deletedDuplicate, deletedOther := asset, duplicate.Media, other.Media
+	deletedAsset.Deleted, deletedDuplicate.Deleted, deletedOther.Deleted = true, true, true
+	s.mediaRepo.EXPECT().
+		ResolveDuplicates(keep.Media.ID, []uuid.UUID{duplicate.Media.ID, other.Media.ID}, []model.Media{deletedAsset, deletedDuplicate, deletedOther}).
+		Return(nil).
+		Times(1)
+
+	err := s.svc.ResolveDuplicates(dto.ResolveDuplicatesDTO{Keep: keep.Media.ID, Remove: []uuid.UUID{duplicate.Media.ID, other.Media.ID}})
+	if err != nil {
+		t.Errorf("was not expecting an error but received: %v", err.Error())
+	}
+}
+
+func Test_ResolveDuplicates_WithMissingMediaToKeep_ShouldReturnNotFound(t *testing.T) {
+	s := setup(t)
+	defer s.cleanup()
+
+	id := uuid.New()
+	s.mediaRepo.EXPECT().GetById(id).Return(nil, nil).Times(1)
+	s.mediaRepo.EXPECT().ResolveDuplicates(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := s.svc.ResolveDuplicates(dto.ResolveDuplicatesDTO{Keep: id, Remove: []uuid.UUID{uuid.New()}})
+	if !errors.Is(err, ErrMediaNotFound) {
+		t.Errorf("expected a not found error but got: %v", err)
+	}
+}
+
+func Test_ResolveDuplicates_Physical_ShouldOnlyRemoveFilesOnceCommitted(t *testing.T) {
+	s := setup(t)
+	defer s.cleanup()
+	s.svc.env = &environment.EnvironmentVariables{Assets: s.assetsPath}
+
+	a := "a"
+	keep := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &a}}
+	duplicate := models.Media{Media: model.Media{ID: uuid.New(), Checksum: &a, Exists: true, Path: s.mediaFile}}
+	resolve := dto.ResolveDuplicatesDTO{Keep: keep.Media.ID, Remove: []uuid.UUID{duplicate.Media.ID}, Physical: true}
+
+	s.mediaRepo.EXPECT().GetById(keep.Media.ID).Return(&keep, nil).Times(2)
+	s.mediaRepo.EXPECT().GetById(duplicate.Media.ID).Return(&duplicate, nil).Times(2)
+	s.mediaRepo.EXPECT().GetAssetsFor(duplicate.Media.ID).Return([]model.Media{}, nil).Times(2)
+
+	deleted := duplicate.Media
+	deleted.Deleted, deleted.Exists = true, false
+	gomock.InOrder(
+		s.mediaRepo.EXPECT().ResolveDuplicates(keep.Media.ID, resolve.Remove, []model.Media{deleted}).Return(errors.New("rolled back")).Times(1),
+		s.mediaRepo.EXPECT().ResolveDuplicates(keep.Media.ID, resolve.Remove, []model.Media{deleted}).Return(nil).Times(1),
+	)
+
+	if err := s.svc.ResolveDuplicates(resolve); err == nil {
+		t.Errorf("expected an error when the transaction failed")
+	}
+	if _, err := os.Stat(s.mediaFile); err != nil {
+		t.Errorf("expected the file to be kept when the transaction failed but got: %v", err)
+	}
+
+	if err := s.svc.ResolveDuplicates(resolve); err != nil {
+		t.Errorf("was not expecting an error but received: %v", err.Error())
+	}
+	if _, err := os.Stat(s.mediaFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the file to be removed but got: %v", err)
+	}
+}
+
+func Test_IsDuplicate_WithChecksumsOfDifferentAlgorithms_ShouldComparePartialChecksums(t *testing.T) {
+	full, partial := "a", "b"
+	md5, sha256 := model.ChecksumAlgorithmEnum_Md5, model.ChecksumAlgorithmEnum_Sha256
+	keep := models.Media{Media: model.Media{Checksum: &full, ChecksumAlgorithm: &md5, PartialChecksum: &partial, PartialChecksumAlgorithm: &sha256}}
+	duplicate := models.Media{Media: model.Media{Checksum: &full, ChecksumAlgorithm: &sha256, PartialChecksum: &partial, PartialChecksumAlgorithm: &sha256}}
+
+	if !isDuplicate(keep, duplicate) {
+		t.Errorf("expected media with the same partial checksum to be duplicates")
+	}
+
+	other := "c"
+	duplicate.PartialChecksum = &other
+	if isDuplicate(keep, duplicate) {
+		t.Errorf("expected media with different partial checksums not to be duplicates")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/slugger7/exorcist/internal/environment"
 	errs "github.com/slugger7/exorcist/internal/errors"
 	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/models"
 	"github.com/slugger7/exorcist/internal/repository"
 	personService "github.com/slugger7/exorcist/internal/service/person"
 	tagService "github.com/slugger7/exorcist/internal/service/tag"
@@ -22,6 +23,10 @@ type MediaService interface {
 	AddPerson(id uuid.UUID, personId uuid.UUID) (*model.MediaPerson, error)
 	Delete(id uuid.UUID, physical bool) error
 	LogProgress(id, userId uuid.UUID, progress dto.ProgressUpdateDTO) (*model.MediaProgress, error)
+	GetDuplicates(by dto.DuplicateKey) ([][]models.DuplicateMedia, error)
+	ResolveDuplicates(resolve dto.ResolveDuplicatesDTO) error
+	// GetNearDuplicates returns pairs of videos with fingerprints that are at least as similar as the threshold
+	GetNearDuplicates(threshold float64) ([]models.NearDuplicate, error)
 }
 
 type mediaService struct {
@@ -87,30 +92,46 @@ func (m *mediaService) Delete(id uuid.UUID, physical bool) error {
 	}
 
 	if physical {
-		// unsure if I want to move the removal logic to a method. Feels weird to just have it hanging about here.
-		assetsPath := path.Join(m.env.Assets, mediaEntity.Media.ID.String())
-		if err = os.RemoveAll(assetsPath); err != nil {
-			return errs.BuildError(err, "could not remove assets and assets folder: (%v)", assetsPath)
+		if err := m.removeFiles(mediaEntity.Media); err != nil {
+			return err
 		}
+	}
 
-		if err = os.Remove(mediaEntity.Media.Path); err != nil {
-			return errs.BuildError(err, "could not remove media: (%v)", mediaEntity.Path)
+	for _, d := range deletedMedia(mediaEntity.Media, assets, physical) {
+		if err := m.repo.Media().Delete(d); err != nil {
+			return errs.BuildError(err, "something failed while deleting media (%v) in repo: %v", d.ID.String(), id.String())
 		}
-
-		mediaEntity.Media.Exists = false
 	}
 
-	mediaEntity.Media.Deleted = true
+	return nil
+}
+
+// deletedMedia is the media and its assets marked as deleted, assets first. Media that is deleted physically no longer exists
+func deletedMedia(mediaEntity model.Media, assets []model.Media, physical bool) []model.Media {
+	deleted := make([]model.Media, 0, len(assets)+1)
 	for _, a := range assets {
 		a.Deleted = true
 		a.Exists = !physical
-		if err := m.repo.Media().Delete(a); err != nil {
-			return errs.BuildError(err, "something failed while deleting an asset (%v) in repo: %v", a.ID.String(), id.String())
-		}
+		deleted = append(deleted, a)
+	}
+
+	mediaEntity.Deleted = true
+	if physical {
+		mediaEntity.Exists = false
+	}
+
+	return append(deleted, mediaEntity)
+}
+
+// removeFiles removes the file of the media and the folder of its assets
+func (m *mediaService) removeFiles(mediaEntity model.Media) error {
+	assetsPath := path.Join(m.env.Assets, mediaEntity.ID.String())
+	if err := os.RemoveAll(assetsPath); err != nil {
+		return errs.BuildError(err, "could not remove assets and assets folder: (%v)", assetsPath)
 	}
 
-	if err := m.repo.Media().Delete(mediaEntity.Media); err != nil {
-		return errs.BuildError(err, "something failed while deleting media in repo: %v", id.String())
+	if err := os.Remove(mediaEntity.Path); err != nil {
+		return errs.BuildError(err, "could not remove media: (%v)", mediaEntity.Path)
 	}
 
 	return nil
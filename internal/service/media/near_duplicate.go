@@ -0,0 +1,73 @@
+package mediaService
+
+import (
+	"math"
+	"slices"
+
+	errs "github.com/slugger7/exorcist/internal/errors"
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/models"
+)
+
+// nearDuplicateRuntimeTolerance is how much the runtimes of near duplicates can differ as a fraction of the runtime.
+// Re-encodes can gain or lose a few frames so there is a minimum of nearDuplicateMinRuntimeTolerance seconds
+const (
+	nearDuplicateRuntimeTolerance    = 0.01
+	nearDuplicateMinRuntimeTolerance = 2.0
+)
+
+// GetNearDuplicates implements MediaService.
+func (m *mediaService) GetNearDuplicates(threshold float64) ([]models.NearDuplicate, error) {
+	fingerprinted, err := m.repo.Media().GetFingerprinted()
+	if err != nil {
+		return nil, errs.BuildError(err, "could not get fingerprinted media from repo")
+	}
+
+	return nearDuplicates(fingerprinted, threshold), nil
+}
+
+// nearDuplicates compares the fingerprints of videos with about the same runtime and returns the pairs that are at
+// least as similar as the threshold, most similar first. The videos have to be ordered by runtime
+func nearDuplicates(videos []models.DuplicateMedia, threshold float64) []models.NearDuplicate {
+	fingerprints := make([]media.Fingerprint, len(videos))
+	for i, v := range videos {
+		if v.Video != nil && v.Video.Fingerprint != nil {
+			fingerprints[i] = media.FingerprintFromBytes(*v.Video.Fingerprint)
+		}
+	}
+
+	pairs := []models.NearDuplicate{}
+	for i, a := range videos {
+		if a.Video == nil {
+			continue
+		}
+
+		tolerance := math.Max(a.Video.Runtime*nearDuplicateRuntimeTolerance, nearDuplicateMinRuntimeTolerance)
+		for j := i + 1; j < len(videos); j++ {
+			b := videos[j]
+			if b.Video == nil {
+				continue
+			}
+			if b.Video.Runtime-a.Video.Runtime > tolerance {
+				break
+			}
+
+			similarity := fingerprints[i].Similarity(fingerprints[j])
+			if similarity >= threshold {
+				pairs = append(pairs, models.NearDuplicate{A: a, B: b, Similarity: similarity})
+			}
+		}
+	}
+
+	slices.SortStableFunc(pairs, func(x, y models.NearDuplicate) int {
+		switch {
+		case x.Similarity > y.Similarity:
+			return -1
+		case x.Similarity < y.Similarity:
+			return 1
+		}
+		return 0
+	})
+
+	return pairs
+}
@@ -0,0 +1,57 @@
+package mediaService
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/media"
+	"github.com/slugger7/exorcist/internal/models"
+)
+
+func fingerprinted(runtime float64, fingerprint media.Fingerprint) models.DuplicateMedia {
+	bytes := fingerprint.Bytes()
+	return models.DuplicateMedia{
+		Media: model.Media{ID: uuid.New()},
+		Video: &model.Video{Runtime: runtime, Fingerprint: &bytes},
+	}
+}
+
+func Test_NearDuplicates_ShouldReturnSimilarPairsMostSimilarFirst(t *testing.T) {
+	videos := []models.DuplicateMedia{
+		fingerprinted(60, media.Fingerprint{0, 0}),
+		fingerprinted(60.5, media.Fingerprint{0b1, 0}),
+		fingerprinted(61, media.Fingerprint{0, 0}),
+	}
+
+	pairs := nearDuplicates(videos, 0.99)
+
+	if len(pairs) != 3 {
+		t.Fatalf("expected three pairs but got: %v", len(pairs))
+	}
+	if pairs[0].Similarity != 1 || pairs[0].A.Media.ID != videos[0].Media.ID || pairs[0].B.Media.ID != videos[2].Media.ID {
+		t.Errorf("expected the identical pair first but got: %v", pairs[0])
+	}
+}
+
+func Test_NearDuplicates_ShouldNotCompareVideosWithDifferentRuntimes(t *testing.T) {
+	videos := []models.DuplicateMedia{
+		fingerprinted(60, media.Fingerprint{0}),
+		fingerprinted(600, media.Fingerprint{0}),
+	}
+
+	if pairs := nearDuplicates(videos, 0.5); len(pairs) != 0 {
+		t.Errorf("expected no pairs but got: %v", pairs)
+	}
+}
+
+func Test_NearDuplicates_ShouldLeaveOutPairsBelowThreshold(t *testing.T) {
+	videos := []models.DuplicateMedia{
+		fingerprinted(60, media.Fingerprint{0}),
+		fingerprinted(60, media.Fingerprint{0xffff_ffff}),
+	}
+
+	if pairs := nearDuplicates(videos, 0.9); len(pairs) != 0 {
+		t.Errorf("expected no pairs but got: %v", pairs)
+	}
+}
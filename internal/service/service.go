@@ -4,9 +4,11 @@ import (
 	"context"
 
 	"github.com/slugger7/exorcist/internal/environment"
+	jobRegistry "github.com/slugger7/exorcist/internal/job/registry"
 	"github.com/slugger7/exorcist/internal/logger"
 	"github.com/slugger7/exorcist/internal/repository"
 	jobService "github.com/slugger7/exorcist/internal/service/job"
+	jobScheduleService "github.com/slugger7/exorcist/internal/service/job_schedule"
 	libraryService "github.com/slugger7/exorcist/internal/service/library"
 	libraryPathService "github.com/slugger7/exorcist/internal/service/library_path"
 	mediaService "github.com/slugger7/exorcist/internal/service/media"
@@ -21,6 +23,7 @@ type Service interface {
 	Library() libraryService.LibraryService
 	LibraryPath() libraryPathService.LibraryPathService
 	Job() jobService.JobService
+	JobSchedule() jobScheduleService.JobScheduleService
 	Person() personService.PersonService
 	Tag() tagService.TagService
 	Media() mediaService.MediaService
@@ -34,6 +37,7 @@ type service struct {
 	library     libraryService.LibraryService
 	libraryPath libraryPathService.LibraryPathService
 	job         jobService.JobService
+	jobSchedule jobScheduleService.JobScheduleService
 	person      personService.PersonService
 	tag         tagService.TagService
 	media       mediaService.MediaService
@@ -43,17 +47,19 @@ type service struct {
 
 var serviceInstance *service
 
-func New(repo repository.Repository, env *environment.EnvironmentVariables, jobCh chan bool, ctx context.Context) Service {
+func New(repo repository.Repository, env *environment.EnvironmentVariables, ctx context.Context, jobs jobRegistry.Builder) Service {
 	if serviceInstance == nil {
 		personService := personService.New(repo, env)
 		tagService := tagService.New(repo, env)
+		jobService := jobService.New(repo, env, ctx, jobs)
 		serviceInstance = &service{
 			env:         env,
 			logger:      logger.New(env),
 			user:        userService.New(repo, env),
 			library:     libraryService.New(repo, env),
 			libraryPath: libraryPathService.New(repo, env),
-			job:         jobService.New(repo, env, jobCh, ctx),
+			job:         jobService,
+			jobSchedule: jobScheduleService.New(env, repo, jobService),
 			person:      personService,
 			tag:         tagService,
 			media:       mediaService.New(env, repo, personService, tagService),
@@ -86,6 +92,11 @@ func (s *service) Job() jobService.JobService {
 	return s.job
 }
 
+func (s *service) JobSchedule() jobScheduleService.JobScheduleService {
+	s.logger.Debug("Getting jobScheduleService")
+	return s.jobSchedule
+}
+
 func (s *service) Person() personService.PersonService {
 	s.logger.Debug("Getting personService")
 	return s.person
@@ -0,0 +1,88 @@
+package watcher
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/dto"
+	"github.com/slugger7/exorcist/internal/job"
+	"github.com/slugger7/exorcist/internal/media"
+)
+
+// maxScanPaths is the number of changed paths above which the whole library path is scanned instead
+const maxScanPaths = 500
+
+// debounce collects the changed paths until there were no changes for the debounce interval and then scans them
+func (w *Watcher) debounce(ctx context.Context, libraryPath model.LibraryPath, changes <-chan string) {
+	defer w.wg.Done()
+
+	timer := time.NewTimer(w.debounceInterval())
+	timer.Stop()
+	defer timer.Stop()
+
+	pending := map[string]bool{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case path := <-changes:
+			pending[path] = true
+			timer.Reset(w.debounceInterval())
+		case <-timer.C:
+			w.scan(libraryPath, collapsePaths(pending))
+			pending = map[string]bool{}
+		}
+	}
+}
+
+// scan creates a scan path job for the changed paths
+func (w *Watcher) scan(libraryPath model.LibraryPath, paths []string) {
+	var scanJob *model.Job
+	var err error
+	if len(paths) > maxScanPaths || slices.Contains(paths, libraryPath.Path) {
+		scanJob, err = job.CreateScanPathJob(libraryPath.ID, nil, dto.JobPriority_Medium)
+	} else {
+		scanJob, err = job.CreateScanFilesJob(libraryPath.ID, paths)
+	}
+	if err != nil {
+		w.logger.Errorf("could not create scan job for changes in %v: %v", libraryPath.Path, err.Error())
+		return
+	}
+
+	created, skipped, err := w.repo.Job().CreateAll([]model.Job{*scanJob})
+	if err != nil {
+		w.logger.Errorf("could not save scan job for changes in %v: %v", libraryPath.Path, err.Error())
+		return
+	}
+
+	if len(skipped) > 0 {
+		w.logger.Infof("Skipped scanning %v changes in %v as the same scan is pending", len(paths), libraryPath.Path)
+	}
+
+	for _, j := range created {
+		w.logger.Infof("Scanning %v changes in %v", len(paths), libraryPath.Path)
+		w.ws.JobCreate(j)
+	}
+}
+
+// collapsePaths returns the sorted paths without the ones that are in another of the paths
+func collapsePaths(paths map[string]bool) []string {
+	sorted := []string{}
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	slices.Sort(sorted)
+
+	collapsed := []string{}
+	for _, p := range sorted {
+		if slices.ContainsFunc(collapsed, func(c string) bool { return media.IsInPath(c, p) }) {
+			continue
+		}
+
+		collapsed = append(collapsed, p)
+	}
+
+	return collapsed
+}
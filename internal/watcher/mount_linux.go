@@ -0,0 +1,30 @@
+//go:build linux
+
+package watcher
+
+import "syscall"
+
+// file system types of which changes made by other machines are not sent as inotify events
+var networkFileSystems = []uint32{
+	0x6969,     // nfs
+	0x517b,     // smb
+	0xff534d42, // cifs
+	0xfe534d42, // smb2
+	0x65735546, // fuse, used by sshfs and rclone
+}
+
+// isNetworkMount checks if the path is on a network file system
+func isNetworkMount(path string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false
+	}
+
+	for _, t := range networkFileSystems {
+		if uint32(stat.Type) == t {
+			return true
+		}
+	}
+
+	return false
+}
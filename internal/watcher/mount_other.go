@@ -0,0 +1,8 @@
+//go:build !linux
+
+package watcher
+
+// isNetworkMount is only implemented for linux. Other platforms rely on the notify watcher failing
+func isNetworkMount(path string) bool {
+	return false
+}
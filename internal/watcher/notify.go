@@ -0,0 +1,78 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	errs "github.com/slugger7/exorcist/internal/errors"
+)
+
+// notify sends the paths of file system notifications in the root until the context is done.
+// An error is returned when the root could not be watched
+func (w *Watcher) notify(ctx context.Context, root string, changes chan<- string) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errs.BuildError(err, "could not create file system watcher")
+	}
+	defer fsw.Close()
+
+	if err := addRecursive(fsw, root); err != nil {
+		return errs.BuildError(err, "could not watch directories in %v", root)
+	}
+
+	w.logger.Infof("Watching %v for file system notifications", root)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-fsw.Events:
+			if !ok {
+				w.logger.Warningf("Stopped receiving file system notifications for %v", root)
+				return nil
+			}
+
+			if !e.Has(fsnotify.Create) && !e.Has(fsnotify.Write) && !e.Has(fsnotify.Remove) && !e.Has(fsnotify.Rename) {
+				continue
+			}
+
+			// directories are not watched recursively so new ones are added along with their children
+			if e.Has(fsnotify.Create) {
+				if info, err := os.Stat(e.Name); err == nil && info.IsDir() {
+					if err := addRecursive(fsw, e.Name); err != nil {
+						w.logger.Warningf("could not watch created directory %v: %v", e.Name, err.Error())
+					}
+				}
+			}
+
+			sendChange(ctx, changes, e.Name)
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+
+			w.logger.Warningf("file system watcher error for %v: %v", root, err.Error())
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// notifications were dropped so everything is scanned
+				sendChange(ctx, changes, root)
+			}
+		}
+	}
+}
+
+func addRecursive(fsw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return fsw.Add(path)
+		}
+
+		return nil
+	})
+}
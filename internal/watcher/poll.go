@@ -0,0 +1,95 @@
+package watcher
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	errs "github.com/slugger7/exorcist/internal/errors"
+)
+
+type fileState struct {
+	size     int64
+	modified time.Time
+}
+
+// poll sends the paths that were created, removed or modified between walks of the root until the context is done
+func (w *Watcher) poll(ctx context.Context, root string, changes chan<- string) {
+	ticker := time.NewTicker(w.pollInterval())
+	defer ticker.Stop()
+
+	previous, err := snapshot(root)
+	if err != nil {
+		w.logger.Warningf("could not poll %v: %v", root, err.Error())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current, err := snapshot(root)
+			if err != nil {
+				// an unavailable mount should not be reported as all of its files being removed
+				w.logger.Warningf("could not poll %v: %v", root, err.Error())
+				continue
+			}
+
+			if previous != nil {
+				for _, path := range diffSnapshots(previous, current) {
+					sendChange(ctx, changes, path)
+				}
+			}
+			previous = current
+		}
+	}
+}
+
+// snapshot returns the state of every file in the root
+func snapshot(root string) (map[string]fileState, error) {
+	files := map[string]fileState{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		files[path] = fileState{size: info.Size(), modified: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, errs.BuildError(err, "could not walk %v", root)
+	}
+
+	return files, nil
+}
+
+// diffSnapshots returns the paths that were created, removed or modified
+func diffSnapshots(previous, current map[string]fileState) []string {
+	changed := []string{}
+	for path, state := range current {
+		if p, ok := previous[path]; !ok || p.size != state.size || !p.modified.Equal(state.modified) {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
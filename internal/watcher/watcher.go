@@ -0,0 +1,180 @@
+package watcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/slugger7/exorcist/internal/db/exorcist/public/model"
+	"github.com/slugger7/exorcist/internal/environment"
+	"github.com/slugger7/exorcist/internal/logger"
+	"github.com/slugger7/exorcist/internal/repository"
+	"github.com/slugger7/exorcist/internal/websockets"
+)
+
+// reconcileInterval is the time between checks for library paths of which the watch mode changed in another process
+const reconcileInterval = 5 * time.Minute
+
+// Watcher watches the library paths that have a watch mode for created, removed and renamed files
+// and creates scan path jobs for only the files that changed
+type Watcher struct {
+	env         *environment.EnvironmentVariables
+	repo        repository.Repository
+	logger      logger.Logger
+	ws          websockets.Websockets
+	shutdownCtx context.Context
+	wg          *sync.WaitGroup
+	reload      chan bool
+	watches     map[uuid.UUID]*pathWatch
+}
+
+type pathWatch struct {
+	libraryPath model.LibraryPath
+	cancel      context.CancelFunc
+}
+
+var watcherInstance *Watcher
+
+func New(
+	env *environment.EnvironmentVariables,
+	repo repository.Repository,
+	logger logger.Logger,
+	shutdownCtx context.Context,
+	wg *sync.WaitGroup,
+	ws websockets.Websockets,
+) *Watcher {
+	if watcherInstance == nil {
+		watcherInstance = &Watcher{
+			env:         env,
+			repo:        repo,
+			logger:      logger,
+			ws:          ws,
+			shutdownCtx: shutdownCtx,
+			wg:          wg,
+			reload:      make(chan bool, 1),
+			watches:     map[uuid.UUID]*pathWatch{},
+		}
+
+		wg.Add(1)
+		go watcherInstance.loop()
+	}
+
+	return watcherInstance
+}
+
+// Reload picks up library paths of which the watch mode changed
+func (w *Watcher) Reload() {
+	select {
+	case w.reload <- true:
+	default: // a reload is already pending
+	}
+}
+
+func (w *Watcher) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+
+	w.reconcile()
+	for {
+		select {
+		case <-w.shutdownCtx.Done():
+			w.logger.Debug("Shutdown signal received. Stopping watcher")
+			return
+		case <-w.reload:
+			w.reconcile()
+		case <-ticker.C:
+			w.reconcile()
+		}
+	}
+}
+
+// reconcile starts watching the library paths that have a watch mode and stops watching the ones that do not anymore
+func (w *Watcher) reconcile() {
+	libraryPaths, err := w.repo.LibraryPath().GetWatched()
+	if err != nil {
+		w.logger.Errorf("could not get watched library paths: %v", err.Error())
+		return
+	}
+
+	watched := map[uuid.UUID]model.LibraryPath{}
+	for _, l := range libraryPaths {
+		watched[l.ID] = l
+	}
+
+	for id, pw := range w.watches {
+		l, ok := watched[id]
+		if ok && l.Path == pw.libraryPath.Path && l.Watch == pw.libraryPath.Watch {
+			continue
+		}
+
+		w.logger.Infof("Stopped watching %v", pw.libraryPath.Path)
+		pw.cancel()
+		delete(w.watches, id)
+	}
+
+	for id, l := range watched {
+		if _, ok := w.watches[id]; ok {
+			continue
+		}
+
+		w.watches[id] = w.start(l)
+	}
+}
+
+func (w *Watcher) start(libraryPath model.LibraryPath) *pathWatch {
+	ctx, cancel := context.WithCancel(w.shutdownCtx)
+	changes := make(chan string)
+
+	w.wg.Add(2)
+	go w.watch(ctx, libraryPath, changes)
+	go w.debounce(ctx, libraryPath, changes)
+
+	return &pathWatch{
+		libraryPath: libraryPath,
+		cancel:      cancel,
+	}
+}
+
+// watch sends the paths that changed in the library path until the context is done.
+// Polling is used when file system notifications are not available for the library path
+func (w *Watcher) watch(ctx context.Context, libraryPath model.LibraryPath, changes chan<- string) {
+	defer w.wg.Done()
+
+	if libraryPath.Watch == model.WatchModeEnum_Notify {
+		if isNetworkMount(libraryPath.Path) {
+			w.logger.Warningf("%v is a network mount which does not send file system notifications. Falling back to polling", libraryPath.Path)
+		} else if err := w.notify(ctx, libraryPath.Path, changes); err != nil {
+			w.logger.Warningf("could not watch %v for file system notifications. Falling back to polling: %v", libraryPath.Path, err.Error())
+		} else {
+			return
+		}
+	}
+
+	w.logger.Infof("Polling %v for changes every %v", libraryPath.Path, w.pollInterval())
+	w.poll(ctx, libraryPath.Path, changes)
+}
+
+func (w *Watcher) pollInterval() time.Duration {
+	if w.env.WatchPollInterval < 1 {
+		return time.Minute
+	}
+	return time.Duration(w.env.WatchPollInterval) * time.Second
+}
+
+func (w *Watcher) debounceInterval() time.Duration {
+	if w.env.WatchDebounce < 1 {
+		return time.Second
+	}
+	return time.Duration(w.env.WatchDebounce) * time.Second
+}
+
+// sendChange sends the changed path unless the context is done
+func sendChange(ctx context.Context, changes chan<- string, path string) {
+	select {
+	case <-ctx.Done():
+	case changes <- path:
+	}
+}
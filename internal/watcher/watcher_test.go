@@ -0,0 +1,61 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiffSnapshots_ShouldReturnCreatedRemovedAndModifiedPaths(t *testing.T) {
+	now := time.Now()
+	previous := map[string]fileState{
+		"/removed":   {size: 1, modified: now},
+		"/modified":  {size: 1, modified: now},
+		"/unchanged": {size: 1, modified: now},
+	}
+	current := map[string]fileState{
+		"/created":   {size: 1, modified: now},
+		"/modified":  {size: 2, modified: now},
+		"/unchanged": {size: 1, modified: now},
+	}
+
+	actual := diffSnapshots(previous, current)
+	slices.Sort(actual)
+
+	assert.Equal(t, []string{"/created", "/modified", "/removed"}, actual)
+}
+
+func Test_CollapsePaths_ShouldRemovePathsInOtherPaths(t *testing.T) {
+	paths := map[string]bool{
+		"/library/dir/video.mp4": true,
+		"/library/dir":           true,
+		"/library/dir2/file.mp4": true,
+		"/library/a.mp4":         true,
+	}
+
+	actual := collapsePaths(paths)
+
+	assert.Equal(t, []string{"/library/a.mp4", "/library/dir", "/library/dir2/file.mp4"}, actual)
+}
+
+func Test_Snapshot_ShouldContainFilesInSubdirectories(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "nested", "video.mp4")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(nested), os.ModePerm))
+	assert.Nil(t, os.WriteFile(nested, []byte("video"), 0644))
+
+	actual, err := snapshot(root)
+	assert.Nil(t, err)
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, int64(5), actual[nested].size)
+}
+
+func Test_Snapshot_WithMissingRoot_ShouldReturnError(t *testing.T) {
+	_, err := snapshot(filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, err)
+}
@@ -28,3 +28,15 @@ func (w *websockets) JobCreate(job model.Job) {
 
 	message.SendToAll(w.wss)
 }
+
+// JobProgress implements Websockets.
+func (w *websockets) JobProgress(progress dto.JobProgressDTO) {
+	w.logger.Debug("ws - job progress")
+
+	message := dto.WSMessage[dto.JobProgressDTO]{
+		Topic: dto.WSTopic_JobProgress,
+		Data:  progress,
+	}
+
+	message.SendToAll(w.wss)
+}
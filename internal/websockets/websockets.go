@@ -26,6 +26,7 @@ type Websockets interface {
 	MediaCreate(media dto.MediaOverviewDTO)
 	JobUpdate(job model.Job)
 	JobCreate(job model.Job)
+	JobProgress(progress dto.JobProgressDTO)
 }
 
 type websockets struct {
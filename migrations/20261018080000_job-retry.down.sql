@@ -0,0 +1,2 @@
+alter table job drop column run_after;
+alter table job drop column attempts;
@@ -0,0 +1,2 @@
+alter table job add column attempts smallint not null default 0;
+alter table job add column run_after timestamp not null default current_timestamp;
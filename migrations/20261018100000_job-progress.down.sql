@@ -0,0 +1,4 @@
+alter table job drop column progress_message;
+alter table job drop column progress_total;
+alter table job drop column progress_processed;
+alter table job drop column started;
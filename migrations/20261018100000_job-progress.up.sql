@@ -0,0 +1,4 @@
+alter table job add column started timestamp;
+alter table job add column progress_processed integer not null default 0;
+alter table job add column progress_total integer not null default 0;
+alter table job add column progress_message varchar;
@@ -0,0 +1,2 @@
+alter table job drop column heartbeat;
+alter table job drop column worker;
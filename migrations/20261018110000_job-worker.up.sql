@@ -0,0 +1,2 @@
+alter table job add column worker varchar;
+alter table job add column heartbeat timestamp;
@@ -0,0 +1,2 @@
+drop trigger if exists job_created_notify on job;
+drop function if exists notify_job_created();
@@ -0,0 +1,11 @@
+create or replace function notify_job_created() returns trigger as $$
+begin
+  perform pg_notify('job_created', '');
+  return null;
+end;
+$$ language plpgsql;
+
+create trigger job_created_notify
+after insert on job
+for each statement
+execute function notify_job_created();
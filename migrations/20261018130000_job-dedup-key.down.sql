@@ -0,0 +1,2 @@
+drop index uq_job_dedup_key_active;
+alter table job drop column dedup_key;
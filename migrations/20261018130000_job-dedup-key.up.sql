@@ -0,0 +1,2 @@
+alter table job add column dedup_key varchar;
+create unique index uq_job_dedup_key_active on job (dedup_key) where status in ('not_started', 'in_progress');
@@ -0,0 +1,4 @@
+alter table job_log drop constraint fk_job_log_job;
+drop table job_log;
+
+drop type job_log_level_enum;
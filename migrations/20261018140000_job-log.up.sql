@@ -0,0 +1,13 @@
+create type job_log_level_enum as enum ('info', 'warning', 'error');
+
+create table job_log
+(
+  id uuid primary key default gen_random_uuid(),
+  job_id uuid not null,
+  level job_log_level_enum not null,
+  message text not null,
+  created timestamp default current_timestamp not null,
+  constraint fk_job_log_job
+    foreign key(job_id) references job(id)
+    on delete cascade
+);
@@ -0,0 +1,3 @@
+alter table library_path drop column watch;
+
+drop type watch_mode_enum;
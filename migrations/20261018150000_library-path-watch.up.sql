@@ -0,0 +1,3 @@
+create type watch_mode_enum as enum ('off', 'notify', 'poll');
+
+alter table library_path add column watch watch_mode_enum default 'off' not null;
@@ -0,0 +1 @@
+alter table media drop column file_modified;
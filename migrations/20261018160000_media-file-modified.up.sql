@@ -0,0 +1 @@
+alter table media add column file_modified timestamp;
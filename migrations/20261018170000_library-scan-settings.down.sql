@@ -0,0 +1 @@
+alter table library drop column scan_settings;
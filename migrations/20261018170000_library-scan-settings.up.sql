@@ -0,0 +1 @@
+alter table library add column scan_settings jsonb;
@@ -0,0 +1,14 @@
+alter table video drop column fingerprint;
+
+delete from job where job_type in ('generate_fingerprint', 'generate_library_fingerprints');
+delete from job_schedule where job_type in ('generate_fingerprint', 'generate_library_fingerprints');
+
+alter type job_type_enum rename to old_job_type_enum;
+create type job_type_enum as enum
+  ('update_existing_videos', 'scan_path', 'generate_checksum', 'generate_thumbnail', 'scan_library',
+   'refresh_metadata', 'refresh_library_metadata', 'generate_chapters', 'generate_library_chapters');
+alter table job alter column job_type drop default;
+alter table job alter column job_type type job_type_enum using job_type::text::job_type_enum;
+alter table job alter column job_type set default 'scan_path';
+alter table job_schedule alter column job_type type job_type_enum using job_type::text::job_type_enum;
+drop type old_job_type_enum;
@@ -0,0 +1,4 @@
+alter type job_type_enum add value 'generate_fingerprint'; -- samples frames of a video and stores their perceptual hashes
+alter type job_type_enum add value 'generate_library_fingerprints';
+
+alter table video add column fingerprint bytea;
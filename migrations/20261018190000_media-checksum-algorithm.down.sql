@@ -0,0 +1,8 @@
+drop index if exists idx_media_partial_checksum;
+alter table media drop column partial_checksum_algorithm;
+alter table media drop column partial_checksum;
+
+update media set checksum = null where checksum_algorithm is distinct from 'md5';
+alter table media drop column checksum_algorithm;
+
+drop type checksum_algorithm_enum;
@@ -0,0 +1,9 @@
+create type checksum_algorithm_enum as enum ('md5', 'sha256', 'sha512', 'xxh3');
+
+alter table media add column checksum_algorithm checksum_algorithm_enum;
+update media set checksum_algorithm = 'md5' where checksum is not null;
+
+-- hash of the size and the first and last few megabytes of the file
+alter table media add column partial_checksum text;
+alter table media add column partial_checksum_algorithm checksum_algorithm_enum;
+create index idx_media_partial_checksum on media(partial_checksum);
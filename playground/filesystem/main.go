@@ -9,7 +9,7 @@ import (
 
 func main() {
 	fmt.Println("Finding values")
-	values, err := media.GetFilesByExtensions(".", []string{".mp4", ".m4v", ".mkv", ".avi", ".wmv", ".flv", ".webm", ".f4v", ".mpg", ".m2ts", ".mov"})
+	values, _, err := media.GetFilesByExtensions(".", []string{".mp4", ".m4v", ".mkv", ".avi", ".wmv", ".flv", ".webm", ".f4v", ".mpg", ".m2ts", ".mov"}, nil)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -17,6 +17,12 @@ mockgen -source=${REPO_DIR}/repository.go > ${MOCK_REPO_DIR}/repository.go
 mkdir -p ${MOCK_REPO_DIR}/job
 mockgen -source=${REPO_DIR}/job/job.go >  ${MOCK_REPO_DIR}/job/job.go
 
+mkdir -p ${MOCK_REPO_DIR}/job_log
+mockgen -source=${REPO_DIR}/job_log/job_log.go >  ${MOCK_REPO_DIR}/job_log/job_log.go
+
+mkdir -p ${MOCK_REPO_DIR}/job_schedule
+mockgen -source=${REPO_DIR}/job_schedule/job_schedule.go >  ${MOCK_REPO_DIR}/job_schedule/job_schedule.go
+
 mkdir -p ${MOCK_REPO_DIR}/library
 mockgen -source=${REPO_DIR}/library/library.go >  ${MOCK_REPO_DIR}/library/library.go
 
@@ -45,6 +51,9 @@ echo "Generate service mocks"
 mkdir -p ${MOCK_SERVICE_DIR}
 mockgen -source=${SERVICE_DIR}/service.go > ${MOCK_SERVICE_DIR}/service.go
 
+mkdir -p ${MOCK_SERVICE_DIR}/job_schedule
+mockgen -source=${SERVICE_DIR}/job_schedule/job_schedule.go > ${MOCK_SERVICE_DIR}/job_schedule/job_schedule.go
+
 mkdir -p ${MOCK_SERVICE_DIR}/library
 mockgen -source=${SERVICE_DIR}/library/library.go > ${MOCK_SERVICE_DIR}/library/library.go
 